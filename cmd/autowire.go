@@ -0,0 +1,34 @@
+package cmd
+
+import (
+	"github.com/spelens-gud/gsus/internal/runner"
+	"github.com/spf13/cobra"
+)
+
+// autowireCmd var    依赖注入代码生成命令.
+// 该命令扫描带 @autowire 注解的结构体，检查依赖关系后按 set 分组生成构造函数及 Initialize 函数.
+var autowireCmd = &cobra.Command{
+	Use:   "autowire",
+	Short: "生成依赖注入代码",
+	Long:  `扫描配置 autowire.scope 下带 @autowire 注解的结构体，为导出的接口字段注入对应实现，按 set 分组在 autowire.path 下生成构造函数及 Initialize 函数并进行编译检查`,
+	Run: func(cmd *cobra.Command, args []string) {
+		// 调用 runner 执行依赖注入代码生成逻辑
+		runner.RunAutoAutowire(&runner.AutowireOptions{})
+	},
+}
+
+// init function    初始化 autowire 命令.
+// 将 autowire 命令注册为根命令的子命令.
+func init() {
+	rootCmd.AddCommand(autowireCmd)
+
+	// Here you will define your flags and configuration settings.
+
+	// Cobra supports Persistent Flags which will work for this command
+	// and all subcommands, e.g.:
+	// autowireCmd.PersistentFlags().String("foo", "", "A help for foo")
+
+	// Cobra supports local flags which will only run when this command
+	// is called directly, e.g.:
+	// autowireCmd.Flags().BoolP("toggle", "t", false, "Help message for toggle")
+}
@@ -0,0 +1,35 @@
+package cmd
+
+import (
+	"github.com/spelens-gud/gsus/internal/runner"
+	"github.com/spf13/cobra"
+)
+
+// templateCheckCmd var    模板检查命令.
+// 该命令使用示例数据渲染所有已配置的模板，提前发现模板错误.
+var templateCheckCmd = &cobra.Command{
+	Use:   "check",
+	Short: "检查模板",
+	Long:  `使用对应类型的示例数据渲染配置中使用的所有模板，输出模板执行错误的文件及行号，以及生成的 Go 代码中无法解析或无法编译（类型检查未通过）的行`,
+	Args:  cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		// 调用 runner 执行模板检查逻辑
+		runner.RunAutoTemplateCheck(&runner.TemplateOptions{})
+	},
+}
+
+// init function    初始化 template check 命令.
+// 将 check 命令注册为 template 命令的子命令.
+func init() {
+	templateCmd.AddCommand(templateCheckCmd)
+
+	// Here you will define your flags and configuration settings.
+
+	// Cobra supports Persistent Flags which will work for this command
+	// and all subcommands, e.g.:
+	// templateCheckCmd.PersistentFlags().String("foo", "", "A help for foo")
+
+	// Cobra supports local flags which will only run when this command
+	// is called directly, e.g.:
+	// templateCheckCmd.Flags().BoolP("toggle", "t", false, "Help message for toggle")
+}
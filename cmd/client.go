@@ -7,16 +7,19 @@ import (
 
 // clientCmd var    HTTP 客户端代码生成命令.
 // 该命令用于根据服务接口定义自动生成 HTTP 客户端代码.
+// 可选提供客户端代码输出目录，默认使用配置中的 http.client.path.
 var clientCmd = &cobra.Command{
-	Use:   "client [service]",
+	Use:   "client [path]",
 	Short: "生成 HTTP 客户端代码",
-	Long:  `根据服务定义生成 HTTP 客户端相关代码`,
-	Args:  cobra.ExactArgs(1),
+	Long:  `扫描配置 http.scope 下的服务定义，使用 http.client 中的模板在 http.client.path 下生成 HTTP 客户端相关代码`,
+	Args:  cobra.MaximumNArgs(1),
 	Run: func(cmd *cobra.Command, args []string) {
+		opts := &runner.ClientOptions{}
+		if len(args) > 0 {
+			opts.ServicePath = args[0]
+		}
 		// 调用 runner 执行实际的客户端代码生成逻辑
-		runner.RunAutoClient(&runner.ClientOptions{
-			ServicePath: args[0],
-		})
+		runner.RunAutoClient(opts)
 	},
 }
 
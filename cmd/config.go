@@ -10,10 +10,11 @@ import (
 var configCmd = &cobra.Command{
 	Use:   "config",
 	Short: "配置管理",
-	Long:  `管理 gsus 项目配置`,
+	Long:  `查看及修改 gsus 项目配置，不指定子命令时输出生效配置`,
+	Args:  cobra.NoArgs,
 	Run: func(cmd *cobra.Command, args []string) {
-		// 调用 runner 执行配置管理逻辑
-		runner.RunAutoConfig(&runner.ConfigOptions{})
+		// 调用 runner 执行配置输出逻辑
+		runner.RunAutoConfigShow(&runner.ConfigOptions{})
 	},
 }
 
@@ -0,0 +1,41 @@
+package cmd
+
+import (
+	"github.com/spelens-gud/gsus/internal/runner"
+	"github.com/spf13/cobra"
+)
+
+// templateEjectOverwrite var    是否覆盖项目中已存在的模板.
+var templateEjectOverwrite bool
+
+// templateEjectCmd var    模板导出命令.
+// 该命令将用户模板或内置模板复制到项目 .gsus/templates 下，导出后的模板优先生效，可自由修改.
+var templateEjectCmd = &cobra.Command{
+	Use:   "eject <name...>",
+	Short: "导出模板",
+	Long:  `将用户模板（~/.config/gsus/templates）或内置模板导出到项目 .gsus/templates 下以便自定义，导出内置模板时记录原始版本供 gsus update 合并`,
+	Args:  cobra.MinimumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		// 调用 runner 执行模板导出逻辑
+		runner.RunAutoTemplateEject(&runner.TemplateOptions{
+			Names:     args,
+			Overwrite: templateEjectOverwrite,
+		})
+	},
+}
+
+// init function    初始化 template eject 命令.
+// 将 eject 命令注册为 template 命令的子命令，并定义命令标志.
+func init() {
+	templateCmd.AddCommand(templateEjectCmd)
+
+	// Here you will define your flags and configuration settings.
+
+	// Cobra supports Persistent Flags which will work for this command
+	// and all subcommands, e.g.:
+	// templateEjectCmd.PersistentFlags().String("foo", "", "A help for foo")
+
+	// Cobra supports local flags which will only run when this command
+	// is called directly, e.g.:
+	templateEjectCmd.Flags().BoolVar(&templateEjectOverwrite, "overwrite", false, "覆盖项目中已存在的模板")
+}
@@ -6,11 +6,11 @@ import (
 )
 
 // enumCmd var    枚举类型代码生成命令.
-// 该命令用于根据配置文件自动生成 Go 枚举类型代码，支持自定义枚举值和相关方法.
+// 该命令扫描带 @enum 注解的常量块，生成 String/Parse/Values/IsValid 及 JSON/Text 编解码方法.
 var enumCmd = &cobra.Command{
 	Use:   "enum",
 	Short: "生成枚举类型代码",
-	Long:  `根据配置文件生成 Go 枚举类型代码，支持自定义枚举值和方法`,
+	Long:  `扫描配置 enum.scope 下带 @enum 注解的常量块，生成 String、Parse、Values、IsValid 以及 JSON/Text 编解码方法`,
 	Run: func(cmd *cobra.Command, args []string) {
 		// 调用 runner 执行枚举类型代码生成逻辑
 		runner.RunAutoEnum(&runner.EnumOptions{})
@@ -0,0 +1,35 @@
+package cmd
+
+import (
+	"github.com/spelens-gud/gsus/internal/runner"
+	"github.com/spf13/cobra"
+)
+
+// templateFuncsCmd var    模板函数列表命令.
+// 该命令输出所有 gsus 模板可用的函数及用法.
+var templateFuncsCmd = &cobra.Command{
+	Use:   "funcs",
+	Short: "列出模板函数",
+	Long:  `列出所有 gsus 模板（.gsus/templates 下的模板及配置中的路径模板）可用的函数及用法`,
+	Args:  cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		// 调用 runner 执行模板函数输出逻辑
+		runner.RunAutoTemplateFuncs(&runner.TemplateOptions{})
+	},
+}
+
+// init function    初始化 template funcs 命令.
+// 将 funcs 命令注册为 template 命令的子命令.
+func init() {
+	templateCmd.AddCommand(templateFuncsCmd)
+
+	// Here you will define your flags and configuration settings.
+
+	// Cobra supports Persistent Flags which will work for this command
+	// and all subcommands, e.g.:
+	// templateFuncsCmd.PersistentFlags().String("foo", "", "A help for foo")
+
+	// Cobra supports local flags which will only run when this command
+	// is called directly, e.g.:
+	// templateFuncsCmd.Flags().BoolP("toggle", "t", false, "Help message for toggle")
+}
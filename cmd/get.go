@@ -0,0 +1,37 @@
+package cmd
+
+import (
+	"github.com/spelens-gud/gsus/internal/runner"
+	"github.com/spf13/cobra"
+)
+
+// configGetCmd var    读取配置项命令.
+// 该命令输出生效配置中的单个配置项，如 gsus config get db2struct.path.
+var configGetCmd = &cobra.Command{
+	Use:   "get <key>",
+	Short: "读取配置项",
+	Long:  `输出生效配置中的单个配置项，配置项路径以 . 分隔，列表使用下标，如 db2struct.path、impls.0.path`,
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		// 调用 runner 执行配置读取逻辑
+		runner.RunAutoConfigGet(&runner.ConfigOptions{
+			Key: args[0],
+		})
+	},
+}
+
+// init function    初始化 config get 命令.
+// 将 get 命令注册为 config 命令的子命令.
+func init() {
+	configCmd.AddCommand(configGetCmd)
+
+	// Here you will define your flags and configuration settings.
+
+	// Cobra supports Persistent Flags which will work for this command
+	// and all subcommands, e.g.:
+	// configGetCmd.PersistentFlags().String("foo", "", "A help for foo")
+
+	// Cobra supports local flags which will only run when this command
+	// is called directly, e.g.:
+	// configGetCmd.Flags().BoolP("toggle", "t", false, "Help message for toggle")
+}
@@ -0,0 +1,47 @@
+package cmd
+
+import (
+	"github.com/spelens-gud/gsus/internal/runner"
+	"github.com/spf13/cobra"
+)
+
+var (
+	// graphFormat var    导出格式.
+	graphFormat string
+	// graphOutput var    输出文件.
+	graphOutput string
+)
+
+// graphCmd var    依赖关系图导出命令.
+// 该命令根据 @autowire 注解导出 service/dao 之间的依赖关系图，并输出循环依赖、缺少实现等检查结果.
+var graphCmd = &cobra.Command{
+	Use:   "graph",
+	Short: "导出依赖关系图",
+	Long:  `扫描配置 autowire.scope 下带 @autowire 注解的结构体，按 set 分组导出 DOT 或 Mermaid 格式的依赖关系图，同时输出循环依赖、重复实现、缺少实现及未被依赖的检查结果`,
+	Run: func(cmd *cobra.Command, args []string) {
+		// 调用 runner 执行依赖关系图导出逻辑
+		runner.RunAutoAutowireGraph(&runner.AutowireGraphOptions{
+			Format: graphFormat,
+			Output: graphOutput,
+		})
+	},
+}
+
+// init function    初始化 graph 命令.
+// 将 graph 命令注册为 autowire 命令的子命令，并定义命令标志.
+func init() {
+	autowireCmd.AddCommand(graphCmd)
+
+	// Here you will define your flags and configuration settings.
+
+	// Cobra supports Persistent Flags which will work for this command
+	// and all subcommands, e.g.:
+	// graphCmd.PersistentFlags().String("foo", "", "A help for foo")
+
+	// Cobra supports local flags which will only run when this command
+	// is called directly, e.g.:
+	// graphCmd.Flags().BoolP("toggle", "t", false, "Help message for toggle")
+
+	graphCmd.Flags().StringVarP(&graphFormat, "format", "f", "dot", "依赖图格式: dot|mermaid")
+	graphCmd.Flags().StringVarP(&graphOutput, "output", "o", "", "输出文件，默认输出到标准输出")
+}
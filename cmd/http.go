@@ -1,16 +1,21 @@
 package cmd
 
 import (
+	"github.com/spelens-gud/gsus/internal/runner"
 	"github.com/spf13/cobra"
 )
 
 // httpCmd var    HTTP 相关代码生成命令.
-// 该命令是一个父命令，包含 client 和 router 两个子命令.
-// 用于生成 HTTP 客户端或路由相关代码.
+// 该命令是一个父命令，包含 client、router、swagger 和 import 子命令.
+// 用于生成 HTTP 客户端、路由相关代码及接口文档，不带子命令时按配置依次执行 router、client 和 swagger.
 var httpCmd = &cobra.Command{
 	Use:   "http",
 	Short: "HTTP 相关代码生成",
-	Long:  `生成 HTTP 客户端或路由相关代码`,
+	Long:  `生成 HTTP 客户端、路由相关代码及 Swagger 接口文档，不带子命令时按配置 http 依次生成路由、客户端及接口文档`,
+	Run: func(cmd *cobra.Command, args []string) {
+		// 调用 runner 执行全部 HTTP 代码生成逻辑
+		runner.RunAutoHttp(&runner.HttpOptions{})
+	},
 }
 
 // init function    初始化 http 命令.
@@ -10,19 +10,26 @@ var implPrefix string
 
 // implCmd var    接口实现代码生成命令.
 // 该命令用于根据接口定义自动生成实现代码骨架.
-// 需要提供接口名和结构体名两个参数，支持通过 -p 标志指定文件目录前缀.
+// 不带参数时按配置中的 impls 列表同步所有实现集，指定一个参数时仅同步对应名称的实现集.
+// 提供接口名和结构体名两个参数时直接生成，支持通过 -p 标志指定文件目录前缀.
 var implCmd = &cobra.Command{
-	Use:   "impl [interface] [struct]",
+	Use:   "impl [name] | impl [interface] [struct]",
 	Short: "生成接口实现代码",
-	Long:  `根据接口定义自动生成实现代码骨架，支持自定义文件目录前缀`,
-	Args:  cobra.ExactArgs(2),
+	Long:  `根据接口定义自动生成实现代码骨架，不带参数时按配置 impls 同步所有实现集，支持自定义文件目录前缀`,
+	Args:  cobra.RangeArgs(0, 2),
 	Run: func(cmd *cobra.Command, args []string) {
+		opts := &runner.ImplOptions{
+			Prefix: implPrefix,
+		}
+		switch len(args) {
+		case 1:
+			opts.Name = args[0]
+		case 2:
+			opts.Interface = args[0]
+			opts.Struct = args[1]
+		}
 		// 调用 runner 执行接口实现代码生成逻辑
-		runner.RunAutoImpl(&runner.ImplOptions{
-			Interface: args[0],
-			Struct:    args[1],
-			Prefix:    implPrefix,
-		})
+		runner.RunAutoImpl(opts)
 	},
 }
 
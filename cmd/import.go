@@ -0,0 +1,50 @@
+package cmd
+
+import (
+	"github.com/spelens-gud/gsus/internal/runner"
+	"github.com/spf13/cobra"
+)
+
+var (
+	// importPkg var    输出包目录.
+	importPkg string
+	// importOverwrite var    是否覆盖已存在的文件.
+	importOverwrite bool
+)
+
+// importCmd var    OpenAPI 文档导入命令.
+// 该命令根据 OpenAPI 3.x/Swagger 2.0 文档生成带 @service/@http 注解的接口及请求/响应结构体.
+// 生成的接口可直接用于 router、client、swagger 及 impl 代码生成.
+var importCmd = &cobra.Command{
+	Use:   "import <openapi.yaml>",
+	Short: "根据 OpenAPI 文档生成服务接口",
+	Long:  `根据 OpenAPI 3.x/Swagger 2.0 文档(yaml/json)生成带 @service/@http 注解的接口定义及请求/响应结构体，已存在的文件默认先备份为 .bak 文件再覆盖，与 gsus template 相同`,
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		// 调用 runner 执行 OpenAPI 文档导入逻辑
+		runner.RunAutoHttpImport(&runner.HttpImportOptions{
+			Spec:      args[0],
+			Pkg:       importPkg,
+			Overwrite: importOverwrite,
+		})
+	},
+}
+
+// init function    初始化 import 命令.
+// 将 import 命令注册为 http 命令的子命令，并定义命令标志.
+func init() {
+	httpCmd.AddCommand(importCmd)
+
+	// Here you will define your flags and configuration settings.
+
+	// Cobra supports Persistent Flags which will work for this command
+	// and all subcommands, e.g.:
+	// importCmd.PersistentFlags().String("foo", "", "A help for foo")
+
+	// Cobra supports local flags which will only run when this command
+	// is called directly, e.g.:
+	// importCmd.Flags().BoolP("toggle", "t", false, "Help message for toggle")
+
+	importCmd.Flags().StringVar(&importPkg, "pkg", "", "输出包目录，默认为 http.scope")
+	importCmd.Flags().BoolVar(&importOverwrite, "overwrite", false, "覆盖已存在的文件，不生成 .bak 备份")
+}
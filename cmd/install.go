@@ -0,0 +1,37 @@
+package cmd
+
+import (
+	"github.com/spelens-gud/gsus/internal/runner"
+	"github.com/spf13/cobra"
+)
+
+// templatePackInstallCmd var    模板包安装命令.
+// 该命令从本地目录或 .tar.gz 归档安装模板包，无需联网.
+var templatePackInstallCmd = &cobra.Command{
+	Use:   "install <path...>",
+	Short: "安装模板包",
+	Long:  `从本地目录或 .tar.gz 归档安装模板包到 .gsus/packs 下，模板包根目录需包含 pack.yaml 清单，已安装的同名模板包会被替换`,
+	Args:  cobra.MinimumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		// 调用 runner 执行模板包安装逻辑
+		runner.RunAutoTemplatePackInstall(&runner.PackOptions{
+			Sources: args,
+		})
+	},
+}
+
+// init function    初始化 template pack install 命令.
+// 将 install 命令注册为 template pack 命令的子命令.
+func init() {
+	templatePackCmd.AddCommand(templatePackInstallCmd)
+
+	// Here you will define your flags and configuration settings.
+
+	// Cobra supports Persistent Flags which will work for this command
+	// and all subcommands, e.g.:
+	// templatePackInstallCmd.PersistentFlags().String("foo", "", "A help for foo")
+
+	// Cobra supports local flags which will only run when this command
+	// is called directly, e.g.:
+	// templatePackInstallCmd.Flags().BoolP("toggle", "t", false, "Help message for toggle")
+}
@@ -0,0 +1,35 @@
+package cmd
+
+import (
+	"github.com/spelens-gud/gsus/internal/runner"
+	"github.com/spf13/cobra"
+)
+
+// templateListCmd var    模板列表命令.
+// 该命令输出所有可用模板及其生效的来源.
+var templateListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "列出模板",
+	Long:  `列出所有可用模板及公共片段，按 项目 .gsus/templates → 用户 ~/.config/gsus/templates → 内置模板 的顺序显示生效的来源及被覆盖的层级`,
+	Args:  cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		// 调用 runner 执行模板列表输出逻辑
+		runner.RunAutoTemplateList(&runner.TemplateOptions{})
+	},
+}
+
+// init function    初始化 template list 命令.
+// 将 list 命令注册为 template 命令的子命令.
+func init() {
+	templateCmd.AddCommand(templateListCmd)
+
+	// Here you will define your flags and configuration settings.
+
+	// Cobra supports Persistent Flags which will work for this command
+	// and all subcommands, e.g.:
+	// templateListCmd.PersistentFlags().String("foo", "", "A help for foo")
+
+	// Cobra supports local flags which will only run when this command
+	// is called directly, e.g.:
+	// templateListCmd.Flags().BoolP("toggle", "t", false, "Help message for toggle")
+}
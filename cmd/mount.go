@@ -7,11 +7,11 @@ import (
 
 // mountCmd var    挂载相关操作命令.
 // 该命令用于执行挂载相关的代码生成操作.
-// 支持传入多个参数以指定挂载的具体内容.
+// 配置了 mounts 时依次处理每个挂载目标，传入参数时仅处理对应名称的挂载目标.
 var mountCmd = &cobra.Command{
-	Use:   "mount [args...]",
+	Use:   "mount [name...]",
 	Short: "挂载相关操作",
-	Long:  `执行挂载相关的代码生成操作`,
+	Long:  `执行挂载相关的代码生成操作，按配置 mounts 将 @${name} 注解的类型挂载到 ${path} 文件中的 ${struct} 结构体，可通过参数指定要处理的挂载名称`,
 	Run: func(cmd *cobra.Command, args []string) {
 		// 调用 runner 执行挂载操作逻辑
 		runner.RunAutoMount(&runner.MountOptions{
@@ -0,0 +1,35 @@
+package cmd
+
+import (
+	"github.com/spelens-gud/gsus/internal/runner"
+	"github.com/spf13/cobra"
+)
+
+// templatePackCmd var    模板包管理命令.
+// 该命令用于管理项目 .gsus/packs 下安装的模板包，不指定子命令时输出已安装的模板包.
+var templatePackCmd = &cobra.Command{
+	Use:   "pack",
+	Short: "模板包管理",
+	Long:  `管理项目 .gsus/packs 下安装的模板包，配置中通过 包名:模板 引用模板包中的模板，不指定子命令时输出已安装的模板包`,
+	Args:  cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		// 调用 runner 执行模板包列表输出逻辑
+		runner.RunAutoTemplatePackList(&runner.PackOptions{})
+	},
+}
+
+// init function    初始化 template pack 命令.
+// 将 pack 命令注册为 template 命令的子命令.
+func init() {
+	templateCmd.AddCommand(templatePackCmd)
+
+	// Here you will define your flags and configuration settings.
+
+	// Cobra supports Persistent Flags which will work for this command
+	// and all subcommands, e.g.:
+	// templatePackCmd.PersistentFlags().String("foo", "", "A help for foo")
+
+	// Cobra supports local flags which will only run when this command
+	// is called directly, e.g.:
+	// templatePackCmd.Flags().BoolP("toggle", "t", false, "Help message for toggle")
+}
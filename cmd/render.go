@@ -0,0 +1,52 @@
+package cmd
+
+import (
+	"github.com/spelens-gud/gsus/internal/runner"
+	"github.com/spf13/cobra"
+)
+
+var (
+	// templateRenderData var    数据文件路径.
+	templateRenderData string
+	// templateRenderOutput var    输出路径模板.
+	templateRenderOutput string
+	// templateRenderOverwrite var    是否覆盖已存在的文件.
+	templateRenderOverwrite bool
+)
+
+// templateRenderCmd var    数据模板渲染命令.
+// 该命令使用 YAML/JSON 数据文件渲染模板，适用于功能开关、错误码表、权限列表等声明式数据生成代码.
+// 不指定模板时渲染配置中所有设置了 data 的模板.
+var templateRenderCmd = &cobra.Command{
+	Use:   "render [template]",
+	Short: "使用数据文件渲染模板",
+	Long:  `使用 YAML/JSON 数据文件渲染模板，数据文件内容即模板的根数据 .，输出路径同样以数据渲染，覆盖及 .bak 备份规则与 gsus template 相同；未设置输出路径时输出到标准输出`,
+	Args:  cobra.MaximumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		// 调用 runner 执行数据模板渲染逻辑
+		runner.RunAutoTemplateRender(&runner.TemplateOptions{
+			Names:     args,
+			Data:      templateRenderData,
+			Path:      templateRenderOutput,
+			Overwrite: templateRenderOverwrite,
+		})
+	},
+}
+
+// init function    初始化 template render 命令.
+// 将 render 命令注册为 template 命令的子命令，并定义命令标志.
+func init() {
+	templateCmd.AddCommand(templateRenderCmd)
+
+	// Here you will define your flags and configuration settings.
+
+	// Cobra supports Persistent Flags which will work for this command
+	// and all subcommands, e.g.:
+	// templateRenderCmd.PersistentFlags().String("foo", "", "A help for foo")
+
+	// Cobra supports local flags which will only run when this command
+	// is called directly, e.g.:
+	templateRenderCmd.Flags().StringVarP(&templateRenderData, "data", "d", "", "YAML/JSON 数据文件，.json 以外的文件按 YAML 解析")
+	templateRenderCmd.Flags().StringVarP(&templateRenderOutput, "output", "o", "", "输出路径模板，未设置时输出到标准输出")
+	templateRenderCmd.Flags().BoolVar(&templateRenderOverwrite, "overwrite", false, "覆盖已存在的文件")
+}
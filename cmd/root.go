@@ -12,6 +12,7 @@ import (
 	"github.com/charmbracelet/fang"
 	"github.com/charmbracelet/x/exp/charmtone"
 	"github.com/charmbracelet/x/term"
+	"github.com/spelens-gud/gsus/internal/config"
 	"github.com/spelens-gud/gsus/internal/logger"
 	"github.com/spelens-gud/gsus/internal/version"
 	"github.com/spf13/cobra"
@@ -20,6 +21,15 @@ import (
 // commandName 命令名称.
 const commandName = "gsus"
 
+var (
+	// profile 使用的配置 profile.
+	profile string
+	// configFile 配置文件路径.
+	configFile string
+	// projectDir 项目根目录.
+	projectDir string
+)
+
 // rootCmd represents the base command when called without any subcommands.
 var rootCmd = &cobra.Command{
 	Use:   commandName,
@@ -30,6 +40,11 @@ var rootCmd = &cobra.Command{
 - 生成 HTTP 路由代码 (http router)
 - 生成接口实现代码 (impl)
 - 生成枚举类型代码 (enum)`,
+	PersistentPreRun: func(cmd *cobra.Command, args []string) {
+		config.SetProfile(profile)
+		config.SetConfigFile(configFile)
+		config.SetProjectDir(projectDir)
+	},
 }
 
 // versionBit var    版本信息的 ASCII 艺术字样式.
@@ -76,6 +91,9 @@ func init() {
 	// will be global for your application.
 
 	// rootCmd.PersistentFlags().StringVar(&cfgFile, "config", "", "config file (default is $HOME/.gsus.yaml)")
+	rootCmd.PersistentFlags().StringVar(&configFile, "config", "", "配置文件路径，相对路径基于项目目录，默认 .gsus/config.yaml，未指定时读取 "+config.ConfigEnv+" 环境变量")
+	rootCmd.PersistentFlags().StringVar(&projectDir, "project-dir", "", "项目根目录，默认为 go.mod 所在目录，未指定时读取 "+config.ProjectDirEnv+" 环境变量")
+	rootCmd.PersistentFlags().StringVar(&profile, "profile", "", "使用的配置 profile，未指定时读取 "+config.ProfileEnv+" 环境变量")
 
 	// Cobra also supports local flags, which will only run
 	// when this action is called directly.
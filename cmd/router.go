@@ -7,17 +7,19 @@ import (
 
 // routerCmd var    HTTP 路由代码生成命令.
 // 该命令用于根据服务接口定义自动生成 HTTP 路由注册代码.
-// 需要提供服务路径作为参数.
+// 可选提供路由代码输出目录，默认使用配置中的 http.router.path.
 var routerCmd = &cobra.Command{
-	Use:   "router [service]",
+	Use:   "router [path]",
 	Short: "生成 HTTP 路由代码",
-	Long:  `根据服务定义生成 HTTP 路由注册代码`,
-	Args:  cobra.ExactArgs(1),
+	Long:  `扫描配置 http.scope 下的服务定义，使用 http.router.template 模板在 http.router.path 下生成 HTTP 路由注册代码`,
+	Args:  cobra.MaximumNArgs(1),
 	Run: func(cmd *cobra.Command, args []string) {
+		opts := &runner.RouterOptions{}
+		if len(args) > 0 {
+			opts.RouterPath = args[0]
+		}
 		// 调用 runner 执行路由代码生成逻辑
-		runner.RunAutoRouter(&runner.RouterOptions{
-			RouterPath: args[0],
-		})
+		runner.RunAutoRouter(opts)
 	},
 }
 
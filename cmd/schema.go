@@ -0,0 +1,35 @@
+package cmd
+
+import (
+	"github.com/spelens-gud/gsus/internal/runner"
+	"github.com/spf13/cobra"
+)
+
+// configSchemaCmd var    配置 JSON Schema 导出命令.
+// 该命令输出配置文件的 JSON Schema，可配置到编辑器中用于补全及校验.
+var configSchemaCmd = &cobra.Command{
+	Use:   "schema",
+	Short: "输出配置 JSON Schema",
+	Long:  `输出 .gsus/config.yaml 的 JSON Schema，可配置到编辑器（如 yaml-language-server）中用于配置补全及校验`,
+	Args:  cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		// 调用 runner 执行 JSON Schema 导出逻辑
+		runner.RunAutoConfigSchema(&runner.ConfigOptions{})
+	},
+}
+
+// init function    初始化 config schema 命令.
+// 将 schema 命令注册为 config 命令的子命令.
+func init() {
+	configCmd.AddCommand(configSchemaCmd)
+
+	// Here you will define your flags and configuration settings.
+
+	// Cobra supports Persistent Flags which will work for this command
+	// and all subcommands, e.g.:
+	// configSchemaCmd.PersistentFlags().String("foo", "", "A help for foo")
+
+	// Cobra supports local flags which will only run when this command
+	// is called directly, e.g.:
+	// configSchemaCmd.Flags().BoolP("toggle", "t", false, "Help message for toggle")
+}
@@ -0,0 +1,44 @@
+package cmd
+
+import (
+	"github.com/spelens-gud/gsus/internal/runner"
+	"github.com/spf13/cobra"
+)
+
+// configLocal var    是否修改本地覆盖配置.
+var configLocal bool
+
+// configSetCmd var    修改配置项命令.
+// 该命令修改配置文件中的单个配置项，保留配置文件中的注释，如 gsus config set db2struct.type postgresql.
+var configSetCmd = &cobra.Command{
+	Use:   "set <key> <value>",
+	Short: "修改配置项",
+	Long:  `修改 .gsus/config.yaml 中的单个配置项并保留注释，使用 --local 修改 .gsus/config.local.yaml。字符串配置项原样写入，其他配置项按 yaml 解析，如 [int,string]`,
+	Args:  cobra.ExactArgs(2),
+	Run: func(cmd *cobra.Command, args []string) {
+		// 调用 runner 执行配置修改逻辑
+		runner.RunAutoConfigSet(&runner.ConfigOptions{
+			Key:   args[0],
+			Value: args[1],
+			Local: configLocal,
+		})
+	},
+}
+
+// init function    初始化 config set 命令.
+// 将 set 命令注册为 config 命令的子命令，并定义命令标志.
+func init() {
+	configCmd.AddCommand(configSetCmd)
+
+	// Here you will define your flags and configuration settings.
+
+	// Cobra supports Persistent Flags which will work for this command
+	// and all subcommands, e.g.:
+	// configSetCmd.PersistentFlags().String("foo", "", "A help for foo")
+
+	// Cobra supports local flags which will only run when this command
+	// is called directly, e.g.:
+	// configSetCmd.Flags().BoolP("toggle", "t", false, "Help message for toggle")
+
+	configSetCmd.Flags().BoolVar(&configLocal, "local", false, "写入本地配置 .gsus/config.local.yaml")
+}
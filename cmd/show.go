@@ -0,0 +1,35 @@
+package cmd
+
+import (
+	"github.com/spelens-gud/gsus/internal/runner"
+	"github.com/spf13/cobra"
+)
+
+// configShowCmd var    输出生效配置命令.
+// 该命令输出合并本地覆盖配置及 profile 后的生效配置，敏感配置项以 ****** 代替.
+var configShowCmd = &cobra.Command{
+	Use:   "show",
+	Short: "输出生效配置",
+	Long:  `输出合并 .gsus/config.local.yaml 及 --profile 选择的 profile 后的生效配置，数据库密码等敏感配置项以 ****** 代替`,
+	Args:  cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		// 调用 runner 执行配置输出逻辑
+		runner.RunAutoConfigShow(&runner.ConfigOptions{})
+	},
+}
+
+// init function    初始化 config show 命令.
+// 将 show 命令注册为 config 命令的子命令.
+func init() {
+	configCmd.AddCommand(configShowCmd)
+
+	// Here you will define your flags and configuration settings.
+
+	// Cobra supports Persistent Flags which will work for this command
+	// and all subcommands, e.g.:
+	// configShowCmd.PersistentFlags().String("foo", "", "A help for foo")
+
+	// Cobra supports local flags which will only run when this command
+	// is called directly, e.g.:
+	// configShowCmd.Flags().BoolP("toggle", "t", false, "Help message for toggle")
+}
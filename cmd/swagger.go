@@ -0,0 +1,40 @@
+package cmd
+
+import (
+	"github.com/spelens-gud/gsus/internal/runner"
+	"github.com/spf13/cobra"
+)
+
+// swaggerCmd var    接口文档生成命令.
+// 该命令用于根据 @service/@http 注解生成 Swagger 2.0 或 OpenAPI 3.1 接口文档.
+// 可选提供文档输出路径，默认使用配置中的 http.swagger.path.
+var swaggerCmd = &cobra.Command{
+	Use:   "swagger [path]",
+	Short: "生成 Swagger 接口文档",
+	Long:  `根据服务定义中的 @http 注解生成 Swagger(OpenAPI 2.0) 接口文档，配置 http.swagger.version: 3.1 时生成 OpenAPI 3.1 文档`,
+	Args:  cobra.MaximumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		opts := &runner.SwaggerOptions{}
+		if len(args) > 0 {
+			opts.Path = args[0]
+		}
+		// 调用 runner 执行接口文档生成逻辑
+		runner.RunAutoSwagger(opts)
+	},
+}
+
+// init function    初始化 swagger 命令.
+// 将 swagger 命令注册为 http 命令的子命令.
+func init() {
+	httpCmd.AddCommand(swaggerCmd)
+
+	// Here you will define your flags and configuration settings.
+
+	// Cobra supports Persistent Flags which will work for this command
+	// and all subcommands, e.g.:
+	// swaggerCmd.PersistentFlags().String("foo", "", "A help for foo")
+
+	// Cobra supports local flags which will only run when this command
+	// is called directly, e.g.:
+	// swaggerCmd.Flags().BoolP("toggle", "t", false, "Help message for toggle")
+}
@@ -5,15 +5,19 @@ import (
 	"github.com/spf13/cobra"
 )
 
+// updateDryRun var    仅预览更新结果，不写入文件.
+var updateDryRun bool
+
 // updateCmd var    更新模板和配置命令.
 // 该命令用于更新项目中的模板文件和配置文件到最新版本.
 var updateCmd = &cobra.Command{
 	Use:   "update",
 	Short: "更新模板和配置",
-	Long:  `更新项目中的模板文件和配置`,
+	Long: `以 gsus init 时记录的原始模板为基准，将新版默认模板三路合并到 .gsus/templates 下的模板中.
+未修改的模板直接更新，双方修改不重叠时自动合并，重叠时写入冲突标记.`,
 	Run: func(cmd *cobra.Command, args []string) {
 		// 调用 runner 执行更新逻辑
-		runner.RunAutoUpdate(&runner.UpdateOptions{})
+		runner.RunAutoUpdate(&runner.UpdateOptions{DryRun: updateDryRun})
 	},
 }
 
@@ -31,4 +35,5 @@ func init() {
 	// Cobra supports local flags which will only run when this command
 	// is called directly, e.g.:
 	// updateCmd.Flags().BoolP("toggle", "t", false, "Help message for toggle")
+	updateCmd.Flags().BoolVar(&updateDryRun, "dry-run", false, "仅预览变更，不写入文件")
 }
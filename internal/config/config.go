@@ -1,5 +1,15 @@
 package config
 
+// Impl struct    接口实现同步配置.
+// 用于配置带 @${name} 注解的接口的实现生成位置及模板.
+type Impl struct {
+	Name       string `yaml:"name"`       // 实现集名称，对应接口注解 @${name}
+	Scope      string `yaml:"scope"`      // 接口扫描范围
+	Path       string `yaml:"path"`       // 实现结构体文件路径模板
+	StructName string `yaml:"structName"` // 实现结构体名
+	Template   string `yaml:"template"`   // 模板名称
+}
+
 // Db2struct struct    数据库转结构体配置.
 // 包含数据库连接信息和代码生成相关配置.
 type Db2struct struct {
@@ -14,14 +24,37 @@ type Db2struct struct {
 	GenericTemplate string            `yaml:"genericTemplate"` // 泛型模板路径
 	GenericMapTypes []string          `yaml:"genericMapTypes"` // 泛型映射类型列表
 	TypeMap         map[string]string `yaml:"typeMap"`         // 数据库类型到 Go 类型的映射
+	EnumType        bool              `yaml:"enumType"`        // 是否为 ENUM/SET 列生成枚举类型
 }
 
 // Enum struct    枚举生成配置.
 // 用于配置枚举类型代码生成的参数.
 type Enum struct {
 	Scope    string `yaml:"scope"`    // 扫描范围
-	Path     string `yaml:"path"`     // 生成代码的输出路径
-	Template string `yaml:"template"` // 模板文件路径
+	Path     string `yaml:"path"`     // 生成代码的输出路径模板，相对路径基于枚举类型所在目录
+	Template string `yaml:"template"` // 模板名称
+}
+
+// Http struct    HTTP 代码生成配置.
+// 用于配置 @service/@http 注解的扫描范围及接口文档生成.
+type Http struct {
+	Scope   string     `yaml:"scope"`   // 服务定义扫描范围
+	Client  HttpClient `yaml:"client"`  // 客户端生成配置
+	Router  HttpRouter `yaml:"router"`  // 路由生成配置
+	Swagger Swagger    `yaml:"swagger"` // 接口文档配置
+}
+
+// HttpClient struct    HTTP 客户端生成配置.
+type HttpClient struct {
+	Path         string `yaml:"path"`         // 客户端代码输出目录
+	ApiTemplate  string `yaml:"apiTemplate"`  // 调用桩代码模板名称
+	BaseTemplate string `yaml:"baseTemplate"` // 基础客户端模板名称
+}
+
+// HttpRouter struct    HTTP 路由生成配置.
+type HttpRouter struct {
+	Path     string `yaml:"path"`     // 路由代码输出目录
+	Template string `yaml:"template"` // 路由模板名称
 }
 
 // Swagger struct    Swagger 文档配置.
@@ -32,14 +65,26 @@ type Swagger struct {
 	Success     string `yaml:"success"`     // 成功响应模板
 	Failed      string `yaml:"failed"`      // 失败响应模板
 	ProduceType string `yaml:"produceType"` // 响应内容类型
+	Version     string `yaml:"version"`     // 文档版本（2.0/3.1）
+	TypeScope   string `yaml:"typeScope"`   // 请求/响应数据类型扫描范围，默认为项目目录
+}
+
+// Autowire struct    依赖注入配置.
+// 用于配置 @autowire 注解的扫描范围及注入代码输出目录.
+type Autowire struct {
+	Scope    string `yaml:"scope"`    // 扫描范围
+	Path     string `yaml:"path"`     // 注入代码输出目录
+	Template string `yaml:"template"` // 模板名称
 }
 
 // Mount struct    挂载配置.
 // 用于配置代码挂载相关的参数.
 type Mount struct {
-	Scope string   `yaml:"scope"` // 扫描范围
-	Name  string   `yaml:"name"`  // 挂载名称
-	Args  []string `yaml:"args"`  // 挂载参数列表
+	Scope  string   `yaml:"scope"`  // 扫描范围
+	Name   string   `yaml:"name"`   // 挂载名称
+	Path   string   `yaml:"path"`   // 挂载目标文件
+	Struct string   `yaml:"struct"` // 挂载目标结构体
+	Args   []string `yaml:"args"`   // 挂载参数列表
 }
 
 // Gsus struct    gsus 基础配置.
@@ -61,4 +106,5 @@ type Template struct {
 	Path      string `yaml:"path"`      // 生成代码的输出路径
 	Template  string `yaml:"template"`  // 模板文件路径
 	Overwrite bool   `yaml:"overwrite"` // 是否覆盖已存在的文件
+	Data      string `yaml:"data"`      // YAML/JSON 数据文件路径，设置后使用数据文件而非模型渲染，通过 gsus template render 生成
 }
@@ -25,8 +25,18 @@ const (
 	GsusConfigDir = ".gsus"
 	// GsusConfigFile 配置文件路径.
 	GsusConfigFile = GsusConfigDir + string(filepath.Separator) + "config.yaml"
+	// GsusLocalConfigFile 本地覆盖配置文件路径，由 .gsus/.gitignore 忽略.
+	GsusLocalConfigFile = GsusConfigDir + string(filepath.Separator) + "config.local.yaml"
 	// GsusTemplateDir 模板目录路径.
 	GsusTemplateDir = GsusConfigDir + string(filepath.Separator) + "templates"
+	// GsusTemplateBaseDir 模板原始版本目录，记录生成模板时的默认模板，用于 gsus update 三路合并.
+	GsusTemplateBaseDir = GsusConfigDir + string(filepath.Separator) + "templates.base"
+	// GsusPacksDir 已安装模板包目录，每个模板包位于以包名命名的子目录中.
+	GsusPacksDir = GsusConfigDir + string(filepath.Separator) + "packs"
+	// GsusPackManifest 模板包清单文件名.
+	GsusPackManifest = "pack.yaml"
+	// GsusTemplatePartialsDir 模板目录下存放公共模板片段的子目录名.
+	GsusTemplatePartialsDir = "_partials"
 	// GsusTemplateSuffix 模板文件后缀.
 	GsusTemplateSuffix = ".tmpl"
 	// BackupSuffix 备份文件后缀.
@@ -0,0 +1,221 @@
+package config
+
+import (
+	"bytes"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/spelens-gud/gsus/internal/errors"
+	"gopkg.in/yaml.v3"
+)
+
+// redacted 敏感配置项的展示值.
+const redacted = "******"
+
+// Redact method    返回隐藏敏感配置项后的配置副本，用于展示.
+func (o Option) Redact() Option {
+	if len(o.Db2struct.Password) > 0 {
+		o.Db2struct.Password = redacted
+	}
+	if len(o.Profiles) > 0 {
+		profiles := make(map[string]Option, len(o.Profiles))
+		for name, p := range o.Profiles {
+			profiles[name] = p.Redact()
+		}
+		o.Profiles = profiles
+	}
+	return o
+}
+
+// Lookup method    按 db2struct.path、impls.0.path 形式的配置项路径查找生效配置，返回对应的 yaml 节点.
+func (o Option) Lookup(key string) (*yaml.Node, error) {
+	path, _, err := resolveKey(key)
+	if err != nil {
+		return nil, err
+	}
+	var root yaml.Node
+	if err = root.Encode(o); err != nil {
+		return nil, errors.WrapWithCode(err, errors.ErrCodeConfig, fmt.Sprintf("序列化 gsus 配置错误: %s", err))
+	}
+	node := lookupNode(&root, path...)
+	if node == nil {
+		return nil, errors.New(errors.ErrCodeConfig, fmt.Sprintf("配置项 %s 未设置", key))
+	}
+	return node, nil
+}
+
+// SetValue function    在配置文件内容中设置配置项并返回新的文件内容.
+// value 按配置项类型解析，字符串配置项原样写入，其他类型按 yaml 解析，如 [int,string]；其余配置项及注释保持不变.
+func SetValue(content []byte, key, value string) ([]byte, error) {
+	path, typ, err := resolveKey(key)
+	if err != nil {
+		return nil, err
+	}
+
+	// 构造新值节点并检查类型
+	valueNode := &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: value}
+	if typ.Kind() != reflect.String {
+		var doc yaml.Node
+		if err = yaml.Unmarshal([]byte(value), &doc); err != nil {
+			return nil, errors.WrapWithCode(err, errors.ErrCodeConfig, fmt.Sprintf("配置项 %s 的值解析失败: %s", key, err))
+		}
+		valueNode = &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!null"}
+		if len(doc.Content) > 0 {
+			valueNode = doc.Content[0]
+		}
+		var issues configIssues
+		checkFields(valueNode, typ, key, &issues)
+		if len(issues.list) > 0 {
+			return nil, errors.New(errors.ErrCodeConfig, fmt.Sprintf("配置项 %s 的值无效: %s", key, issues.list[0].Message))
+		}
+	}
+
+	var doc yaml.Node
+	if err = yaml.Unmarshal(content, &doc); err != nil {
+		return nil, errors.WrapWithCode(err, errors.ErrCodeConfig, fmt.Sprintf("解析 gsus 配置错误: %s", err))
+	}
+	if len(doc.Content) == 0 {
+		doc = yaml.Node{Kind: yaml.DocumentNode, Content: []*yaml.Node{{Kind: yaml.MappingNode}}}
+	}
+
+	// 逐级查找，缺少的映射自动创建
+	parent := doc.Content[0]
+	for i, p := range path {
+		var (
+			next = valueNode
+			last = i == len(path)-1
+		)
+		if !last {
+			if _, ok := path[i+1].(int); ok {
+				next = &yaml.Node{Kind: yaml.SequenceNode}
+			} else {
+				next = &yaml.Node{Kind: yaml.MappingNode}
+			}
+		}
+		if isNull(parent) {
+			parent.Kind, parent.Tag, parent.Value = yaml.MappingNode, "", ""
+		}
+
+		switch p := p.(type) {
+		case string:
+			if parent.Kind != yaml.MappingNode {
+				return nil, errors.New(errors.ErrCodeConfig, fmt.Sprintf("配置项 %s 在配置文件中不是映射", joinKey(path[:i])))
+			}
+			j := mappingIndex(parent, p)
+			if j < 0 {
+				parent.Content = append(parent.Content, &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: p}, next)
+				parent = next
+				continue
+			}
+			if last {
+				keepComments(next, parent.Content[j+1])
+				parent.Content[j+1] = next
+			}
+			parent = parent.Content[j+1]
+		case int:
+			if parent.Kind != yaml.SequenceNode || p >= len(parent.Content) {
+				return nil, errors.New(errors.ErrCodeConfig, fmt.Sprintf("配置项 %s 在配置文件中不存在", joinKey(path[:i+1])))
+			}
+			if last {
+				keepComments(next, parent.Content[p])
+				parent.Content[p] = next
+			}
+			parent = parent.Content[p]
+		}
+	}
+
+	var buf bytes.Buffer
+	enc := yaml.NewEncoder(&buf)
+	enc.SetIndent(detectIndent(content))
+	if err = enc.Encode(&doc); err != nil {
+		return nil, errors.WrapWithCode(err, errors.ErrCodeConfig, fmt.Sprintf("序列化 gsus 配置错误: %s", err))
+	}
+	_ = enc.Close()
+	return buf.Bytes(), nil
+}
+
+// detectIndent function    返回配置文件内容使用的缩进宽度，无法判断时返回 2.
+func detectIndent(content []byte) int {
+	for _, line := range strings.Split(string(content), "\n") {
+		trimmed := strings.TrimLeft(line, " ")
+		if len(trimmed) == 0 || trimmed[0] == '#' || len(trimmed) == len(line) {
+			continue
+		}
+		if indent := len(line) - len(trimmed); indent >= 2 && indent <= 8 {
+			return indent
+		}
+		break
+	}
+	return 2
+}
+
+// resolveKey function    按配置结构解析配置项路径，返回 yaml 节点路径及配置项类型.
+// 支持 impls.0.path 及 impls[0].path 两种列表下标写法.
+func resolveKey(key string) (path []any, typ reflect.Type, err error) {
+	key = strings.NewReplacer("[", ".", "]", "").Replace(key)
+	typ = reflect.TypeOf(Option{})
+	for _, seg := range strings.Split(key, ".") {
+		if len(seg) == 0 {
+			return nil, nil, errors.New(errors.ErrCodeConfig, fmt.Sprintf("配置项路径格式错误: %s", key))
+		}
+		switch typ.Kind() {
+		case reflect.Struct:
+			fields, names := yamlFields(typ)
+			field, ok := fields[seg]
+			if !ok {
+				return nil, nil, errors.New(errors.ErrCodeConfig, fmt.Sprintf("未知配置项 %s，可选: %s", joinKey(append(path, seg)), strings.Join(names, ", ")))
+			}
+			path, typ = append(path, seg), field.Type
+		case reflect.Slice:
+			index, err := strconv.Atoi(seg)
+			if err != nil || index < 0 {
+				return nil, nil, errors.New(errors.ErrCodeConfig, fmt.Sprintf("配置项 %s 为列表，%s 不是有效的下标", joinKey(path), seg))
+			}
+			path, typ = append(path, index), typ.Elem()
+		case reflect.Map:
+			path, typ = append(path, seg), typ.Elem()
+		default:
+			return nil, nil, errors.New(errors.ErrCodeConfig, fmt.Sprintf("配置项 %s 没有子配置项 %s", joinKey(path), seg))
+		}
+	}
+	return path, typ, nil
+}
+
+// joinKey function    将 yaml 节点路径拼接为配置项路径.
+func joinKey(path []any) string {
+	segs := make([]string, 0, len(path))
+	for _, p := range path {
+		segs = append(segs, fmt.Sprint(p))
+	}
+	return strings.Join(segs, ".")
+}
+
+// mappingIndex function    返回映射中指定键的下标，不存在时返回 -1.
+func mappingIndex(node *yaml.Node, key string) int {
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == key {
+			return i
+		}
+	}
+	return -1
+}
+
+// isNull function    判断节点是否为空值.
+func isNull(node *yaml.Node) bool {
+	return node.Kind == yaml.ScalarNode && node.ShortTag() == "!!null"
+}
+
+// keepComments function    将旧节点的注释保留到新节点.
+func keepComments(dst, src *yaml.Node) {
+	if len(dst.HeadComment) == 0 {
+		dst.HeadComment = src.HeadComment
+	}
+	if len(dst.LineComment) == 0 {
+		dst.LineComment = src.LineComment
+	}
+	if len(dst.FootComment) == 0 {
+		dst.FootComment = src.FootComment
+	}
+}
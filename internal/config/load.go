@@ -3,14 +3,14 @@ package config
 
 import (
 	"fmt"
+	"io/fs"
 	"os"
 	"path/filepath"
+	"strings"
 	"sync"
 
 	"github.com/spelens-gud/gsus/internal/errors"
-	"github.com/spelens-gud/gsus/internal/logger"
 	"github.com/spelens-gud/gsus/internal/utils"
-	"gopkg.in/yaml.v3"
 )
 
 var (
@@ -20,36 +20,114 @@ var (
 	config Option
 	// once 确保配置只加载一次的同步锁.
 	once sync.Once
-	// configPath 配置文件相对路径.
-	configPath = ".gsus/config.yaml"
+	// configFile 通过 --config 指定的配置文件路径.
+	configFile string
+	// profile 通过 --profile 指定的配置 profile.
+	profile string
 )
 
+const (
+	// ProfileEnv 指定配置 profile 的环境变量.
+	ProfileEnv = "GSUS_PROFILE"
+	// ConfigEnv 指定配置文件路径的环境变量.
+	ConfigEnv = "GSUS_CONFIG"
+	// ProjectDirEnv 指定项目根目录的环境变量.
+	ProjectDirEnv = utils.ProjectDirEnv
+)
+
+// SetConfigFile function    设置配置文件路径，优先于 GSUS_CONFIG 环境变量.
+// 需在首次调用 Get 之前设置.
+func SetConfigFile(path string) {
+	configFile = path
+}
+
+// ConfigFile function    返回配置文件路径，未指定时为 .gsus/config.yaml.
+// 相对路径基于项目根目录.
+func ConfigFile() string {
+	if len(configFile) > 0 {
+		return configFile
+	}
+	if path := os.Getenv(ConfigEnv); len(path) > 0 {
+		return path
+	}
+	return GsusConfigFile
+}
+
+// LocalConfigFile function    返回本地覆盖配置文件路径，与配置文件位于同一目录，如 config.yaml 对应 config.local.yaml.
+func LocalConfigFile() string {
+	path := ConfigFile()
+	ext := filepath.Ext(path)
+	return strings.TrimSuffix(path, ext) + ".local" + ext
+}
+
+// UserTemplateDir function    返回用户级模板目录 ~/.config/gsus/templates，设置 XDG_CONFIG_HOME 时使用 $XDG_CONFIG_HOME/gsus/templates.
+// 无法获取用户目录时返回空字符串.
+func UserTemplateDir() string {
+	dir := os.Getenv("XDG_CONFIG_HOME")
+	if len(dir) == 0 {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return ""
+		}
+		dir = filepath.Join(home, ".config")
+	}
+	return filepath.Join(dir, "gsus", "templates")
+}
+
+// SetProjectDir function    设置项目根目录，优先于 GSUS_PROJECT_DIR 环境变量.
+// 需在首次获取项目目录之前设置.
+func SetProjectDir(dir string) {
+	utils.SetProjectDir(dir)
+}
+
+// SetProfile function    设置使用的配置 profile，优先于 GSUS_PROFILE 环境变量.
+// 需在首次调用 Get 之前设置.
+func SetProfile(name string) {
+	profile = name
+}
+
+// Profile function    返回当前使用的配置 profile，未指定时返回空字符串.
+func Profile() string {
+	if len(profile) > 0 {
+		return profile
+	}
+	return os.Getenv(ProfileEnv)
+}
+
 // Get function    获取全局配置.
-// 使用单例模式确保配置只加载一次，返回配置对象和可能的错误.
+// 使用单例模式确保配置只加载一次，合并本地覆盖配置及选中的 profile 并替换环境变量引用，返回生效的配置对象和可能的错误.
 func Get() (Option, error) {
 	once.Do(func() {
-		var content []byte
-		if content, loadError = Load(configPath); loadError != nil {
+		var (
+			content   []byte
+			path      = ConfigFile()
+			localPath = LocalConfigFile()
+		)
+		if content, loadError = Load(path); loadError != nil {
 			loadError = errors.WrapWithCode(loadError, errors.ErrCodeConfig, "加载项目 gsus 配置失败，请运行 [gsus init] 来初始化项目 gsus 配置")
 			return
 		}
-		if loadError = yaml.Unmarshal(content, &config); loadError != nil {
-			loadError = errors.WrapWithCode(loadError, errors.ErrCodeConfig, "反序列化 gsus 配置错误")
+		sources := []configSource{{Path: path, Content: content}}
+
+		// 本地覆盖配置可选，存在时深度合并到主配置之上
+		var local []byte
+		if local, loadError = Load(localPath); loadError == nil {
+			sources = append(sources, configSource{Path: localPath, Content: local})
+		} else if !errors.Is(loadError, fs.ErrNotExist) {
 			return
 		}
+		config, loadError = parse(Profile(), sources...)
 	})
 	return config, loadError
 }
 
 // Load function    加载指定路径的配置文件.
-// 参数 relativePath 是相对于项目根目录的路径.
+// 参数 path 为绝对路径或相对于项目根目录的路径.
 // 返回文件内容字节数组和可能的错误.
-func Load(relativePath string) (content []byte, err error) {
-	dir, err := utils.GetProjectDir()
-	if err != nil {
+func Load(path string) (content []byte, err error) {
+	if err = utils.FixFilepathByProjectDir(&path); err != nil {
 		return nil, errors.WrapWithCode(err, errors.ErrCodeConfig, "获取项目目录失败")
 	}
-	path := filepath.Join(dir, relativePath)
 	content, err = os.ReadFile(path)
 	if err != nil {
 		return nil, errors.WrapWithCode(err, errors.ErrCodeFile, fmt.Sprintf("读取配置文件失败: %s", path))
@@ -63,16 +141,8 @@ func ExecuteWithConfig(fn func(cfg Option) error) {
 	utils.Execute(func() error {
 		cfg, err := Get()
 		if err != nil {
-			logger.Error("加载配置失败: %v", err)
-			return errors.WrapWithCode(err, errors.ErrCodeConfig, fmt.Sprintf("加载配置失败: %s", err))
+			return err
 		}
 		return fn(cfg)
 	})
 }
-
-// Validate method    验证配置的有效性.
-// 检查配置项是否符合要求，返回验证错误.
-func (o *Option) Validate() error {
-	// 添加配置验证逻辑
-	return nil
-}
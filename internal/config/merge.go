@@ -0,0 +1,108 @@
+package config
+
+import (
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// envRegexp 匹配 ${VAR} 及 ${VAR:-default} 形式的环境变量引用.
+var envRegexp = regexp.MustCompile(`\$\{([A-Za-z_][A-Za-z0-9_]*)(?::-([^}]*))?\}`)
+
+// expandEnv function    替换字符串中的环境变量引用，变量未设置或为空时使用默认值.
+func expandEnv(s string) string {
+	return envRegexp.ReplaceAllStringFunc(s, func(ref string) string {
+		m := envRegexp.FindStringSubmatch(ref)
+		if value := os.Getenv(m[1]); len(value) > 0 {
+			return value
+		}
+		return m[2]
+	})
+}
+
+// interpolate function    替换节点中所有值的环境变量引用，映射的键保持不变.
+func interpolate(node *yaml.Node) {
+	switch node.Kind {
+	case yaml.SequenceNode:
+		for _, item := range node.Content {
+			interpolate(item)
+		}
+	case yaml.MappingNode:
+		for i := 1; i < len(node.Content); i += 2 {
+			interpolate(node.Content[i])
+		}
+	case yaml.ScalarNode:
+		if !strings.Contains(node.Value, "${") {
+			return
+		}
+		node.Value = expandEnv(node.Value)
+		// 未加引号的值按替换后的内容重新推断类型，使 port: ${DB_PORT} 可以解析为整数
+		if node.Style&(yaml.DoubleQuotedStyle|yaml.SingleQuotedStyle|yaml.LiteralStyle|yaml.FoldedStyle) == 0 {
+			node.Tag = ""
+		}
+	}
+}
+
+// mergeNode function    将 src 深度合并到 dst 并返回合并结果.
+// 映射逐键合并，列表及单个值整体替换，src 中的空值不覆盖 dst.
+func mergeNode(dst, src *yaml.Node) *yaml.Node {
+	if dst == nil {
+		return src
+	}
+	if isNull(src) {
+		return dst
+	}
+	if dst.Kind != yaml.MappingNode || src.Kind != yaml.MappingNode {
+		return src
+	}
+	for i := 0; i+1 < len(src.Content); i += 2 {
+		key, value := src.Content[i], src.Content[i+1]
+		if j := mappingIndex(dst, key.Value); j >= 0 {
+			dst.Content[j+1] = mergeNode(dst.Content[j+1], value)
+			continue
+		}
+		dst.Content = append(dst.Content, key, value)
+	}
+	return dst
+}
+
+// applyProfile function    检查 profiles 配置并将选中的 profile 深度合并到配置根节点.
+func applyProfile(root *yaml.Node, name string, issues *configIssues) *yaml.Node {
+	var (
+		names    []string
+		selected *yaml.Node
+	)
+	profiles := lookupNode(root, "profiles")
+	if profiles != nil && profiles.Kind == yaml.MappingNode {
+		for i := 0; i+1 < len(profiles.Content); i += 2 {
+			key, value := profiles.Content[i], profiles.Content[i+1]
+			names = append(names, key.Value)
+			if nested := lookupNode(value, "profiles"); nested != nil {
+				issues.add(nested, "profile %s 中不支持嵌套 profiles", key.Value)
+			}
+			if key.Value == name {
+				selected = value
+			}
+		}
+	}
+	if len(name) == 0 {
+		return root
+	}
+	if selected == nil {
+		sort.Strings(names)
+		issues.add(profiles, "配置 profile %s 不存在，可选: %s", name, strings.Join(names, ", "))
+		return root
+	}
+	return mergeNode(root, selected)
+}
+
+// markFile function    记录节点及其所有子节点所属的配置文件.
+func markFile(node *yaml.Node, path string, files map[*yaml.Node]string) {
+	files[node] = path
+	for _, child := range node.Content {
+		markFile(child, path, files)
+	}
+}
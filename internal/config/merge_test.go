@@ -0,0 +1,263 @@
+package config
+
+import (
+	"strings"
+	"testing"
+
+	"gopkg.in/yaml.v3"
+)
+
+// TestExpandEnv function    测试替换环境变量引用.
+func TestExpandEnv(t *testing.T) {
+	t.Setenv("GSUS_TEST_HOST", "db.local")
+	t.Setenv("GSUS_TEST_EMPTY", "")
+
+	tests := []struct {
+		name  string
+		input string
+		want  string
+	}{
+		{
+			name:  "已设置的变量",
+			input: "${GSUS_TEST_HOST}",
+			want:  "db.local",
+		},
+		{
+			name:  "已设置的变量忽略默认值",
+			input: "${GSUS_TEST_HOST:-localhost}",
+			want:  "db.local",
+		},
+		{
+			name:  "未设置的变量",
+			input: "${GSUS_TEST_UNSET}",
+			want:  "",
+		},
+		{
+			name:  "未设置的变量使用默认值",
+			input: "${GSUS_TEST_UNSET:-localhost}",
+			want:  "localhost",
+		},
+		{
+			name:  "空值使用默认值",
+			input: "${GSUS_TEST_EMPTY:-localhost}",
+			want:  "localhost",
+		},
+		{
+			name:  "空默认值",
+			input: "${GSUS_TEST_UNSET:-}",
+			want:  "",
+		},
+		{
+			name:  "默认值包含冒号",
+			input: "${GSUS_TEST_UNSET:-127.0.0.1:3306}",
+			want:  "127.0.0.1:3306",
+		},
+		{
+			name:  "多个引用",
+			input: "${GSUS_TEST_HOST}:${GSUS_TEST_PORT:-3306}",
+			want:  "db.local:3306",
+		},
+		{
+			name:  "字面量 $",
+			input: "pa$$word $HOME $",
+			want:  "pa$$word $HOME $",
+		},
+		{
+			name:  "未闭合的引用",
+			input: "${GSUS_TEST_HOST",
+			want:  "${GSUS_TEST_HOST",
+		},
+		{
+			name:  "非法变量名",
+			input: "${1VAR}",
+			want:  "${1VAR}",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := expandEnv(tt.input); got != tt.want {
+				t.Errorf("expandEnv() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+// TestInterpolate function    测试替换配置节点中的环境变量引用.
+func TestInterpolate(t *testing.T) {
+	t.Setenv("GSUS_TEST_PORT", "3307")
+	t.Setenv("GSUS_TEST_KEY", "replaced")
+
+	content := `db2struct:
+  port: ${GSUS_TEST_PORT}
+  password: "${GSUS_TEST_PORT}"
+  host: ${GSUS_TEST_UNSET:-}
+  typeMap:
+    ${GSUS_TEST_KEY}: ${GSUS_TEST_KEY}
+`
+	var doc yaml.Node
+	if err := yaml.Unmarshal([]byte(content), &doc); err != nil {
+		t.Fatalf("yaml.Unmarshal() error = %v", err)
+	}
+	interpolate(doc.Content[0])
+
+	var o Option
+	if err := doc.Content[0].Decode(&o); err != nil {
+		t.Fatalf("Decode() error = %v", err)
+	}
+	if o.Db2struct.Port != 3307 {
+		t.Errorf("port = %v, want 3307", o.Db2struct.Port)
+	}
+	if o.Db2struct.Password != "3307" {
+		t.Errorf("password = %q, want %q", o.Db2struct.Password, "3307")
+	}
+	if o.Db2struct.Host != "" {
+		t.Errorf("host = %q, want empty", o.Db2struct.Host)
+	}
+	if v, ok := o.Db2struct.TypeMap["${GSUS_TEST_KEY}"]; !ok || v != "replaced" {
+		t.Errorf("typeMap = %v, want key unchanged and value replaced", o.Db2struct.TypeMap)
+	}
+}
+
+// TestParse_Merge function    测试本地配置及 profile 的合并优先级.
+func TestParse_Merge(t *testing.T) {
+	main := `db2struct:
+  type: mysql
+  host: main
+  port: 3306
+  user: root
+impls:
+  - name: service
+  - name: dao
+profiles:
+  prod:
+    db2struct:
+      host: prod
+    impls:
+      - name: prod
+`
+	local := `db2struct:
+  host: local
+  port: 3307
+  user:
+impls:
+  - name: local
+`
+
+	tests := []struct {
+		name    string
+		profile string
+		sources []configSource
+		host    string
+		port    int
+		impls   []string
+	}{
+		{
+			name:    "仅主配置",
+			sources: []configSource{{Path: "config.yaml", Content: []byte(main)}},
+			host:    "main",
+			port:    3306,
+			impls:   []string{"service", "dao"},
+		},
+		{
+			name: "本地配置覆盖主配置，列表整体替换",
+			sources: []configSource{
+				{Path: "config.yaml", Content: []byte(main)},
+				{Path: "config.local.yaml", Content: []byte(local)},
+			},
+			host:  "local",
+			port:  3307,
+			impls: []string{"local"},
+		},
+		{
+			name:    "profile 覆盖主配置",
+			profile: "prod",
+			sources: []configSource{{Path: "config.yaml", Content: []byte(main)}},
+			host:    "prod",
+			port:    3306,
+			impls:   []string{"prod"},
+		},
+		{
+			name:    "profile 覆盖本地配置及主配置",
+			profile: "prod",
+			sources: []configSource{
+				{Path: "config.yaml", Content: []byte(main)},
+				{Path: "config.local.yaml", Content: []byte(local)},
+			},
+			host:  "prod",
+			port:  3307,
+			impls: []string{"prod"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			o, err := parse(tt.profile, tt.sources...)
+			if err != nil {
+				t.Fatalf("parse() error = %v", err)
+			}
+			if o.Db2struct.Host != tt.host {
+				t.Errorf("host = %v, want %v", o.Db2struct.Host, tt.host)
+			}
+			if o.Db2struct.Port != tt.port {
+				t.Errorf("port = %v, want %v", o.Db2struct.Port, tt.port)
+			}
+			// 空值不覆盖已有配置
+			if o.Db2struct.User != "root" {
+				t.Errorf("user = %v, want root", o.Db2struct.User)
+			}
+			var impls []string
+			for _, impl := range o.Impls {
+				impls = append(impls, impl.Name)
+			}
+			if strings.Join(impls, ",") != strings.Join(tt.impls, ",") {
+				t.Errorf("impls = %v, want %v", impls, tt.impls)
+			}
+		})
+	}
+}
+
+// TestParse_Profile function    测试 profile 及本地配置的错误位置.
+func TestParse_Profile(t *testing.T) {
+	tests := []struct {
+		name    string
+		profile string
+		sources []configSource
+		wantErr string
+	}{
+		{
+			name:    "profile 不存在",
+			profile: "staging",
+			sources: []configSource{{Path: "config.yaml", Content: []byte("profiles:\n  prod:\n    db2struct:\n      host: prod\n")}},
+			wantErr: "config.yaml:2:3: 配置 profile staging 不存在，可选: prod",
+		},
+		{
+			name:    "嵌套 profiles",
+			sources: []configSource{{Path: "config.yaml", Content: []byte("profiles:\n  prod:\n    profiles:\n      dev: {}\n")}},
+			wantErr: "config.yaml:4:7: profile prod 中不支持嵌套 profiles",
+		},
+		{
+			name:    "profile 中的未知配置项",
+			profile: "prod",
+			sources: []configSource{{Path: "config.yaml", Content: []byte("profiles:\n  prod:\n    db2struct:\n      hots: prod\n")}},
+			wantErr: "config.yaml:4:7: 未知配置项 db2struct.hots",
+		},
+		{
+			name: "本地配置中的未知配置项",
+			sources: []configSource{
+				{Path: "config.yaml", Content: []byte("db2struct:\n  host: main\n")},
+				{Path: "config.local.yaml", Content: []byte("\ndb2struct:\n  hots: local\n")},
+			},
+			wantErr: "config.local.yaml:3:3: 未知配置项 db2struct.hots",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := parse(tt.profile, tt.sources...)
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("parse() error = %v, want contains %q", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -2,6 +2,8 @@ package config
 
 import (
 	"text/template"
+
+	"gopkg.in/yaml.v3"
 )
 
 // ClientOpt struct    代码生成选项.
@@ -23,6 +25,7 @@ type DbOpt struct {
 	SqlTag         string               // SQL 标签名称
 	PkgName        string               // 包名
 	GenericOption  []func(*TypeOptions) // 通用选项函数列表
+	EnumType       bool                 // 是否为枚举列生成枚举类型
 }
 
 // NewDbOpt function    创建数据库选项.
@@ -114,6 +117,14 @@ func WithSQLInfo() DbOption {
 	}
 }
 
+// WithEnumType function    为枚举列生成枚举类型.
+// 返回一个启用 EnumType 的选项函数.
+func WithEnumType() DbOption {
+	return func(o *DbOpt) {
+		o.EnumType = true
+	}
+}
+
 // HttpOpt struct    HTTP 代码生成选项.
 // 配置 HTTP 客户端和路由代码生成的参数.
 type HttpOpt struct {
@@ -156,8 +167,16 @@ type TypeOptions struct {
 // Option struct    全局配置选项.
 // 包含 gsus 工具的所有配置项，从 YAML 配置文件中加载.
 type Option struct {
-	Gsus      Gsus      `yaml:"gsus"`      // gsus 基础配置
-	Db2struct Db2struct `yaml:"db2struct"` // 数据库转结构体配置
-	Enum      Enum      `yaml:"enum"`      // 枚举生成配置
-	Templates Templates `yaml:"templates"` // 模板配置
+	Gsus      Gsus              `yaml:"gsus"`               // gsus 基础配置
+	Impls     []Impl            `yaml:"impls"`              // 接口实现同步配置
+	Db2struct Db2struct         `yaml:"db2struct"`          // 数据库转结构体配置
+	Enum      Enum              `yaml:"enum"`               // 枚举生成配置
+	Http      Http              `yaml:"http"`               // HTTP 代码生成配置
+	Mounts    []Mount           `yaml:"mounts"`             // 挂载配置
+	Templates Templates         `yaml:"templates"`          // 模板配置
+	Autowire  Autowire          `yaml:"autowire"`           // 依赖注入配置
+	Profiles  map[string]Option `yaml:"profiles,omitempty"` // 命名配置，通过 --profile 或 GSUS_PROFILE 选择并覆盖以上任意配置
+
+	node  *yaml.Node            // 合并后的配置根节点，用于校验错误定位
+	files map[*yaml.Node]string // 节点所属的配置文件
 }
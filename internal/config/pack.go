@@ -0,0 +1,123 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/spelens-gud/gsus/internal/errors"
+	"github.com/spelens-gud/gsus/internal/utils"
+	"gopkg.in/yaml.v3"
+)
+
+// packNameRegexp 匹配合法的模板包名称.
+var packNameRegexp = regexp.MustCompile(`^[a-zA-Z0-9][a-zA-Z0-9_.-]*$`)
+
+// PackManifest struct    模板包清单，位于模板包根目录的 pack.yaml.
+type PackManifest struct {
+	Name        string   `yaml:"name"`                  // 模板包名称，配置中通过 名称:模板 引用
+	Version     string   `yaml:"version"`               // 模板包版本
+	Description string   `yaml:"description,omitempty"` // 模板包说明
+	Templates   []string `yaml:"templates"`             // 模板包提供的模板，模板文件为包根目录下的 模板.tmpl
+	Config      []string `yaml:"config,omitempty"`      // 使用模板包所需的配置项，如 http.scope
+}
+
+// ParsePackRef function    解析 名称:模板 形式的模板包模板引用，不是模板包引用时返回 false.
+func ParsePackRef(ref string) (pack, name string, ok bool) {
+	if filepath.IsAbs(ref) {
+		return "", "", false
+	}
+	pack, name, ok = strings.Cut(ref, ":")
+	if !ok || len(pack) == 0 || len(name) == 0 {
+		return "", "", false
+	}
+	return pack, strings.TrimSuffix(name, GsusTemplateSuffix), true
+}
+
+// ValidTemplateName function    判断模板名称是否合法，拒绝绝对路径及包含 .. 的名称，
+// 除 _partials/ 下的公共片段外不允许包含目录.
+func ValidTemplateName(name string) bool {
+	if len(name) == 0 || filepath.IsAbs(name) || strings.HasPrefix(name, "/") || strings.Contains(name, "\\") {
+		return false
+	}
+	for _, elem := range strings.Split(name, "/") {
+		if elem == ".." || elem == "." || len(elem) == 0 {
+			return false
+		}
+	}
+	return !strings.Contains(name, "/") || strings.HasPrefix(name, GsusTemplatePartialsDir+"/")
+}
+
+// PackDir function    返回已安装模板包的目录.
+func PackDir(pack string) (string, error) {
+	if !packNameRegexp.MatchString(pack) {
+		return "", errors.New(errors.ErrCodeTemplate, fmt.Sprintf("模板包名称不合法: %s", pack))
+	}
+	dir := filepath.Join(GsusPacksDir, pack)
+	if err := utils.FixFilepathByProjectDir(&dir); err != nil {
+		return "", errors.WrapWithCode(err, errors.ErrCodeFile, fmt.Sprintf("无法解析模板包目录: %s", err))
+	}
+	return dir, nil
+}
+
+// LoadPackManifest function    读取并校验模板包目录下的清单文件.
+func LoadPackManifest(dir string) (m PackManifest, err error) {
+	path := filepath.Join(dir, GsusPackManifest)
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return m, errors.WrapWithCode(err, errors.ErrCodeFile, fmt.Sprintf("读取模板包清单失败: %s", path))
+	}
+	if err = yaml.Unmarshal(content, &m); err != nil {
+		return m, errors.WrapWithCode(err, errors.ErrCodeTemplate, fmt.Sprintf("解析模板包清单 %s 失败: %s", path, err))
+	}
+	switch {
+	case !packNameRegexp.MatchString(m.Name):
+		return m, errors.New(errors.ErrCodeTemplate, fmt.Sprintf("模板包清单 %s 中的名称不合法: %q", path, m.Name))
+	case len(m.Version) == 0:
+		return m, errors.New(errors.ErrCodeTemplate, fmt.Sprintf("模板包清单 %s 缺少 version", path))
+	case len(m.Templates) == 0:
+		return m, errors.New(errors.ErrCodeTemplate, fmt.Sprintf("模板包清单 %s 缺少 templates", path))
+	}
+	for _, name := range m.Templates {
+		if !ValidTemplateName(name) || strings.Contains(name, "/") {
+			return m, errors.New(errors.ErrCodeTemplate, fmt.Sprintf("模板包清单 %s 中的模板名称不合法: %q", path, name))
+		}
+	}
+	for _, key := range m.Config {
+		if _, _, err = resolveKey(key); err != nil {
+			return m, errors.WrapWithCode(err, errors.ErrCodeTemplate, fmt.Sprintf("模板包清单 %s 中的配置项错误", path))
+		}
+	}
+	return m, nil
+}
+
+// HasTemplate method    判断模板包是否提供指定模板.
+func (m PackManifest) HasTemplate(name string) bool {
+	for _, t := range m.Templates {
+		if t == name {
+			return true
+		}
+	}
+	return false
+}
+
+// MissingKeys method    返回未在配置文件中设置的配置项.
+func (o *Option) MissingKeys(keys []string) (missing []string) {
+	for _, key := range keys {
+		path, _, err := resolveKey(key)
+		if err != nil {
+			missing = append(missing, key)
+			continue
+		}
+		node := o.lookup(path...)
+		if node != nil && node.Kind == yaml.AliasNode {
+			node = node.Alias
+		}
+		if node == nil || isNull(node) || (node.Kind == yaml.ScalarNode && len(node.Value) == 0) || (node.Kind != yaml.ScalarNode && len(node.Content) == 0) {
+			missing = append(missing, key)
+		}
+	}
+	return missing
+}
@@ -0,0 +1,105 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// TestValidTemplateName function    测试模板名称校验.
+func TestValidTemplateName(t *testing.T) {
+	tests := []struct {
+		name string
+		tmpl string
+		want bool
+	}{
+		{name: "模板", tmpl: "dao", want: true},
+		{name: "公共片段", tmpl: "_partials/header", want: true},
+		{name: "公共片段子目录", tmpl: "_partials/crud/list", want: true},
+		{name: "空名称", tmpl: "", want: false},
+		{name: "绝对路径", tmpl: "/etc/passwd", want: false},
+		{name: "上级目录", tmpl: "../x", want: false},
+		{name: "公共片段中的上级目录", tmpl: "_partials/../../x", want: false},
+		{name: "当前目录", tmpl: "./dao", want: false},
+		{name: "子目录", tmpl: "sub/dao", want: false},
+		{name: "反斜杠", tmpl: `..\x`, want: false},
+		{name: "空路径段", tmpl: "_partials//header", want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ValidTemplateName(tt.tmpl); got != tt.want {
+				t.Errorf("ValidTemplateName(%q) = %v, want %v", tt.tmpl, got, tt.want)
+			}
+		})
+	}
+}
+
+// TestLoadPackManifest function    测试读取并校验模板包清单.
+func TestLoadPackManifest(t *testing.T) {
+	tests := []struct {
+		name     string
+		manifest string
+		wantErr  string
+	}{
+		{
+			name:     "合法清单",
+			manifest: "name: platform\nversion: \"1.0\"\ntemplates: [dao, service]\nconfig: [http.scope]\n",
+		},
+		{
+			name:     "名称不合法",
+			manifest: "name: ../platform\nversion: \"1.0\"\ntemplates: [dao]\n",
+			wantErr:  "中的名称不合法",
+		},
+		{
+			name:     "缺少版本",
+			manifest: "name: platform\ntemplates: [dao]\n",
+			wantErr:  "缺少 version",
+		},
+		{
+			name:     "缺少模板",
+			manifest: "name: platform\nversion: \"1.0\"\n",
+			wantErr:  "缺少 templates",
+		},
+		{
+			name:     "模板名称为绝对路径",
+			manifest: "name: platform\nversion: \"1.0\"\ntemplates: [/etc/passwd]\n",
+			wantErr:  `模板名称不合法: "/etc/passwd"`,
+		},
+		{
+			name:     "模板名称包含上级目录",
+			manifest: "name: platform\nversion: \"1.0\"\ntemplates: [../../x]\n",
+			wantErr:  `模板名称不合法: "../../x"`,
+		},
+		{
+			name:     "模板名称为公共片段",
+			manifest: "name: platform\nversion: \"1.0\"\ntemplates: [_partials/header]\n",
+			wantErr:  "模板名称不合法",
+		},
+		{
+			name:     "未知配置项",
+			manifest: "name: platform\nversion: \"1.0\"\ntemplates: [dao]\nconfig: [http.router.nope]\n",
+			wantErr:  "中的配置项错误",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			if err := os.WriteFile(filepath.Join(dir, GsusPackManifest), []byte(tt.manifest), 0o644); err != nil {
+				t.Fatalf("WriteFile() error = %v", err)
+			}
+			_, err := LoadPackManifest(dir)
+			if len(tt.wantErr) == 0 {
+				if err != nil {
+					t.Errorf("LoadPackManifest() error = %v, want nil", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("LoadPackManifest() error = %v, want contains %q", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,75 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+
+	"github.com/spelens-gud/gsus/internal/errors"
+	"github.com/spelens-gud/gsus/internal/generator/db"
+)
+
+// schemaURI JSON Schema 规范版本.
+const schemaURI = "https://json-schema.org/draft/2020-12/schema"
+
+// Schema function    生成配置文件的 JSON Schema，可用于编辑器补全及校验.
+func Schema() ([]byte, error) {
+	schema := typeSchema(reflect.TypeOf(Option{}), true)
+	schema["$schema"] = schemaURI
+	schema["title"] = "gsus config"
+
+	// 数据库类型及端口范围
+	var types []any
+	for _, t := range db.Types() {
+		types = append(types, string(t))
+	}
+	db2struct := schema["properties"].(map[string]any)["db2struct"].(map[string]any)["properties"].(map[string]any)
+	db2struct["type"].(map[string]any)["enum"] = append(types, "", nil)
+	db2struct["port"].(map[string]any)["minimum"] = 0
+	db2struct["port"].(map[string]any)["maximum"] = 65535
+
+	data, err := json.MarshalIndent(schema, "", "  ")
+	if err != nil {
+		return nil, errors.WrapWithCode(err, errors.ErrCodeConfig, fmt.Sprintf("生成 JSON Schema 失败: %s", err))
+	}
+	return append(data, '\n'), nil
+}
+
+// typeSchema function    按 yaml 字段生成类型的 JSON Schema，除根节点外均允许空值.
+// profiles 中的配置引用根节点.
+func typeSchema(typ reflect.Type, root bool) map[string]any {
+	for typ.Kind() == reflect.Ptr {
+		typ = typ.Elem()
+	}
+	if !root && typ == reflect.TypeOf(Option{}) {
+		return map[string]any{"$ref": "#"}
+	}
+
+	var schema map[string]any
+	switch typ.Kind() {
+	case reflect.Struct:
+		fields, _ := yamlFields(typ)
+		properties := make(map[string]any, len(fields))
+		for name, field := range fields {
+			properties[name] = typeSchema(field.Type, false)
+		}
+		schema = map[string]any{"type": "object", "properties": properties, "additionalProperties": false}
+	case reflect.Slice:
+		schema = map[string]any{"type": "array", "items": typeSchema(typ.Elem(), false)}
+	case reflect.Map:
+		schema = map[string]any{"type": "object", "additionalProperties": typeSchema(typ.Elem(), false)}
+	case reflect.Bool:
+		schema = map[string]any{"type": "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		schema = map[string]any{"type": "integer"}
+	case reflect.Float32, reflect.Float64:
+		schema = map[string]any{"type": "number"}
+	default:
+		schema = map[string]any{"type": "string"}
+	}
+	if !root {
+		schema["type"] = []any{schema["type"], "null"}
+	}
+	return schema
+}
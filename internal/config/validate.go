@@ -0,0 +1,417 @@
+package config
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/spelens-gud/gsus/internal/errors"
+	"github.com/spelens-gud/gsus/internal/generator/db"
+	"github.com/spelens-gud/gsus/internal/logger"
+	"github.com/spelens-gud/gsus/internal/template/builtin"
+	"github.com/spelens-gud/gsus/internal/utils"
+	"github.com/spelens-gud/gsus/internal/validator"
+	"gopkg.in/yaml.v3"
+)
+
+// yamlLineRegexp 匹配 yaml 错误信息中的行号前缀.
+var yamlLineRegexp = regexp.MustCompile(`^(?:yaml: )?line (\d+): `)
+
+// legacyKeys 旧版本配置中已废弃的配置项，按所属结构体记录，解析时忽略并输出警告.
+var legacyKeys = map[reflect.Type]map[string]bool{
+	reflect.TypeOf(Impl{}):  {"pkgPrefix": true},
+	reflect.TypeOf(Mount{}): {"template": true, "passive": true},
+}
+
+// configIssue struct    配置校验问题.
+type configIssue struct {
+	File    string // 所在配置文件
+	Line    int    // 行号，0 表示无法定位
+	Column  int    // 列号，0 表示无法定位
+	Message string // 描述
+}
+
+// configIssues struct    配置校验问题列表.
+type configIssues struct {
+	files map[*yaml.Node]string // 节点所属的配置文件，未记录的节点属于主配置文件
+	list  []configIssue
+}
+
+// add method    记录配置校验问题，node 为空时不附带位置.
+func (is *configIssues) add(node *yaml.Node, format string, args ...any) {
+	issue := configIssue{File: ConfigFile(), Message: fmt.Sprintf(format, args...)}
+	if node != nil {
+		issue.Line, issue.Column = node.Line, node.Column
+		if file, ok := is.files[node]; ok {
+			issue.File = file
+		}
+	}
+	is.list = append(is.list, issue)
+}
+
+// warn method    输出不影响解析的配置问题，格式与校验问题相同.
+func (is *configIssues) warn(node *yaml.Node, format string, args ...any) {
+	issue := configIssue{File: ConfigFile(), Line: node.Line, Column: node.Column, Message: fmt.Sprintf(format, args...)}
+	if file, ok := is.files[node]; ok {
+		issue.File = file
+	}
+	logger.Warn("%s", issue)
+}
+
+// err method    汇总配置校验问题.
+func (is *configIssues) err() error {
+	if len(is.list) == 0 {
+		return nil
+	}
+	lines := make([]string, 0, len(is.list))
+	for _, issue := range is.list {
+		lines = append(lines, issue.String())
+	}
+	return errors.New(errors.ErrCodeConfig, fmt.Sprintf("gsus 配置校验失败:\n%s", strings.Join(lines, "\n")))
+}
+
+// String method    返回配置问题描述，格式为 config.yaml:行:列: 描述.
+func (i configIssue) String() string {
+	switch {
+	case i.Column > 0:
+		return fmt.Sprintf("%s:%d:%d: %s", i.File, i.Line, i.Column, i.Message)
+	case i.Line > 0:
+		return fmt.Sprintf("%s:%d: %s", i.File, i.Line, i.Message)
+	default:
+		return fmt.Sprintf("%s: %s", i.File, i.Message)
+	}
+}
+
+// configSource struct    配置来源.
+type configSource struct {
+	Path    string // 配置文件路径
+	Content []byte // 配置文件内容
+}
+
+// parse function    严格解析并合并配置来源，靠后的来源覆盖靠前的来源，profile 不为空时再合并对应的命名配置.
+// 合并前替换环境变量引用，拒绝未知配置项及类型不匹配的值，解析完成后执行语义校验，错误信息附带文件及行列号.
+func parse(profile string, sources ...configSource) (o Option, err error) {
+	var (
+		root   *yaml.Node
+		issues = configIssues{files: make(map[*yaml.Node]string)}
+	)
+	for _, source := range sources {
+		var doc yaml.Node
+		if err = yaml.Unmarshal(source.Content, &doc); err != nil {
+			issue := configIssue{File: source.Path, Message: strings.TrimPrefix(err.Error(), "yaml: ")}
+			if m := yamlLineRegexp.FindStringSubmatch(err.Error()); m != nil {
+				_, _ = fmt.Sscan(m[1], &issue.Line)
+				issue.Message = err.Error()[len(m[0]):]
+			}
+			issues.list = append(issues.list, issue)
+			return o, issues.err()
+		}
+		if len(doc.Content) == 0 {
+			continue
+		}
+		markFile(doc.Content[0], source.Path, issues.files)
+		interpolate(doc.Content[0])
+		root = mergeNode(root, doc.Content[0])
+	}
+	if root == nil {
+		if len(profile) > 0 {
+			issues.add(nil, "配置 profile %s 不存在", profile)
+		}
+		return o, issues.err()
+	}
+
+	root = applyProfile(root, profile, &issues)
+
+	checkFields(root, reflect.TypeOf(o), "", &issues)
+	if err = issues.err(); err != nil {
+		return o, err
+	}
+	if err = root.Decode(&o); err != nil {
+		return o, errors.WrapWithCode(err, errors.ErrCodeConfig, fmt.Sprintf("反序列化 gsus 配置错误: %s", err))
+	}
+	o.node, o.files = root, issues.files
+	return o, o.Validate()
+}
+
+// checkFields function    按配置结构体检查 yaml 节点，记录未知配置项及类型不匹配的值.
+func checkFields(node *yaml.Node, typ reflect.Type, path string, issues *configIssues) {
+	if node.Kind == yaml.AliasNode {
+		node = node.Alias
+	}
+	if node.Kind == yaml.ScalarNode && node.ShortTag() == "!!null" {
+		return
+	}
+	for typ.Kind() == reflect.Ptr {
+		typ = typ.Elem()
+	}
+
+	switch typ.Kind() {
+	case reflect.Struct:
+		if node.Kind != yaml.MappingNode {
+			issues.add(node, "%s 应为映射", displayPath(path))
+			return
+		}
+		fields, names := yamlFields(typ)
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			key, value := node.Content[i], node.Content[i+1]
+			if key.Tag == "!!merge" {
+				checkFields(value, typ, path, issues)
+				continue
+			}
+			field, ok := fields[key.Value]
+			if !ok && legacyKeys[typ][key.Value] {
+				issues.warn(key, "配置项 %s 已废弃并被忽略，请从配置文件中删除", joinPath(path, key.Value))
+				continue
+			}
+			if !ok {
+				issues.add(key, "未知配置项 %s，可选: %s", joinPath(path, key.Value), strings.Join(names, ", "))
+				continue
+			}
+			checkFields(value, field.Type, joinPath(path, key.Value), issues)
+		}
+	case reflect.Slice:
+		if node.Kind != yaml.SequenceNode {
+			issues.add(node, "%s 应为列表", displayPath(path))
+			return
+		}
+		for i, item := range node.Content {
+			checkFields(item, typ.Elem(), fmt.Sprintf("%s[%d]", path, i), issues)
+		}
+	case reflect.Map:
+		if node.Kind != yaml.MappingNode {
+			issues.add(node, "%s 应为映射", displayPath(path))
+			return
+		}
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			checkFields(node.Content[i+1], typ.Elem(), joinPath(path, node.Content[i].Value), issues)
+		}
+	default:
+		if node.Kind != yaml.ScalarNode {
+			issues.add(node, "%s 应为单个值", displayPath(path))
+			return
+		}
+		if err := node.Decode(reflect.New(typ).Interface()); err != nil {
+			msg := err.Error()
+			if e, ok := err.(*yaml.TypeError); ok && len(e.Errors) > 0 {
+				msg = yamlLineRegexp.ReplaceAllString(e.Errors[0], "")
+			}
+			issues.add(node, "%s: %s", displayPath(path), msg)
+		}
+	}
+}
+
+// yamlFields function    返回结构体的 yaml 字段映射及排序后的字段名.
+func yamlFields(typ reflect.Type) (fields map[string]reflect.StructField, names []string) {
+	fields = make(map[string]reflect.StructField)
+	for i := 0; i < typ.NumField(); i++ {
+		field := typ.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		name, _, _ := strings.Cut(field.Tag.Get("yaml"), ",")
+		if name == "-" {
+			continue
+		}
+		if len(name) == 0 {
+			name = strings.ToLower(field.Name)
+		}
+		fields[name] = field
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return
+}
+
+// joinPath function    拼接配置项路径.
+func joinPath(path, key string) string {
+	if len(path) == 0 {
+		return key
+	}
+	return path + "." + key
+}
+
+// displayPath function    返回配置项路径的展示名称.
+func displayPath(path string) string {
+	if len(path) == 0 {
+		return "配置文件"
+	}
+	return path
+}
+
+// lookup method    按配置项路径查找 yaml 节点，不存在时返回 nil.
+func (o *Option) lookup(path ...any) *yaml.Node {
+	return lookupNode(o.node, path...)
+}
+
+// lookupNode function    按配置项路径查找 yaml 节点，路径元素为映射键或列表下标，不存在时返回 nil.
+func lookupNode(node *yaml.Node, path ...any) *yaml.Node {
+	for _, p := range path {
+		if node == nil {
+			return nil
+		}
+		if node.Kind == yaml.AliasNode {
+			node = node.Alias
+		}
+		var next *yaml.Node
+		switch p := p.(type) {
+		case string:
+			if node.Kind != yaml.MappingNode {
+				return nil
+			}
+			for i := 0; i+1 < len(node.Content); i += 2 {
+				if node.Content[i].Value == p {
+					next = node.Content[i+1]
+				}
+			}
+		case int:
+			if node.Kind != yaml.SequenceNode || p >= len(node.Content) {
+				return nil
+			}
+			next = node.Content[p]
+		}
+		node = next
+	}
+	return node
+}
+
+// Validate method    验证配置的有效性.
+// 检查数据库类型、端口、模板名称及路径模板，返回附带行列号的验证错误.
+func (o *Option) Validate() error {
+	issues := configIssues{files: o.files}
+
+	// 数据库
+	if len(o.Db2struct.Type) > 0 {
+		var types []string
+		for _, t := range db.Types() {
+			types = append(types, string(t))
+		}
+		if err := validator.ValidateOneOf(o.Db2struct.Type, types, "db2struct.type"); err != nil {
+			issues.add(o.lookup("db2struct", "type"), "不支持的数据库类型: %s", err)
+		}
+	}
+	if o.Db2struct.Port != 0 {
+		if err := validator.ValidatePort(o.Db2struct.Port); err != nil {
+			issues.add(o.lookup("db2struct", "port"), "数据库端口错误: %s", err)
+		}
+	}
+	o.checkTemplate(&issues, o.Db2struct.GenericTemplate, "db2struct", "genericTemplate")
+
+	// 接口实现
+	for i, impl := range o.Impls {
+		o.checkTemplate(&issues, impl.Template, "impls", i, "template")
+		o.checkPathTemplate(&issues, impl.Path, "impls", i, "path")
+	}
+
+	// HTTP
+	o.checkTemplate(&issues, o.Http.Client.ApiTemplate, "http", "client", "apiTemplate")
+	o.checkTemplate(&issues, o.Http.Client.BaseTemplate, "http", "client", "baseTemplate")
+	o.checkTemplate(&issues, o.Http.Router.Template, "http", "router", "template")
+	o.checkPathTemplate(&issues, o.Http.Swagger.Success, "http", "swagger", "success")
+	o.checkPathTemplate(&issues, o.Http.Swagger.Failed, "http", "swagger", "failed")
+
+	// 枚举及依赖注入
+	o.checkTemplate(&issues, o.Enum.Template, "enum", "template")
+	o.checkPathTemplate(&issues, o.Enum.Path, "enum", "path")
+	o.checkTemplate(&issues, o.Autowire.Template, "autowire", "template")
+
+	// 模板生成，未指定模板文件时使用与名称同名的模板
+	for i, t := range o.Templates.Templates {
+		if len(t.Template) > 0 {
+			o.checkTemplate(&issues, t.Template, "templates", "templates", i, "template")
+		} else {
+			o.checkTemplate(&issues, t.Name, "templates", "templates", i, "name")
+		}
+		o.checkPathTemplate(&issues, t.Path, "templates", "templates", i, "path")
+		o.checkDataFile(&issues, t.Data, "templates", "templates", i, "data")
+	}
+	return issues.err()
+}
+
+// checkDataFile method    检查数据模板的数据文件是否存在，相对路径按项目目录解析.
+func (o *Option) checkDataFile(issues *configIssues, name string, path ...any) {
+	if len(name) == 0 {
+		return
+	}
+	file := name
+	if err := utils.FixFilepathByProjectDir(&file); err != nil {
+		return
+	}
+	if _, err := os.Stat(file); err != nil {
+		issues.add(o.lookup(path...), "数据文件 %s 不存在", name)
+	}
+}
+
+// checkTemplate method    检查模板能否在项目模板目录、用户模板目录或内置模板中找到.
+func (o *Option) checkTemplate(issues *configIssues, name string, path ...any) {
+	if len(name) == 0 {
+		return
+	}
+	if pack, tmpl, ok := ParsePackRef(name); ok {
+		o.checkPackTemplate(issues, pack, tmpl, path...)
+		return
+	}
+	if filepath.IsAbs(name) {
+		if _, err := os.Stat(name); err != nil {
+			issues.add(o.lookup(path...), "模板 %s 不存在", name)
+		}
+		return
+	}
+
+	name = strings.TrimSuffix(filepath.ToSlash(name), GsusTemplateSuffix)
+	dirs := []string{UserTemplateDir()}
+	if dir := GsusTemplateDir; utils.FixFilepathByProjectDir(&dir) == nil {
+		dirs = append(dirs, dir)
+	}
+	for _, dir := range dirs {
+		if len(dir) == 0 {
+			continue
+		}
+		if _, err := os.Stat(filepath.Join(dir, filepath.FromSlash(name)+GsusTemplateSuffix)); err == nil {
+			return
+		}
+	}
+	if _, ok := builtin.Read(name); ok {
+		return
+	}
+	issues.add(o.lookup(path...), "模板 %s 不存在，执行 gsus template list 查看可用模板", name)
+}
+
+// checkPackTemplate method    检查模板包是否已安装并提供指定模板，以及模板包所需的配置项是否已设置.
+func (o *Option) checkPackTemplate(issues *configIssues, pack, name string, path ...any) {
+	dir, err := PackDir(pack)
+	if err != nil {
+		issues.add(o.lookup(path...), "%s", err)
+		return
+	}
+	m, err := LoadPackManifest(dir)
+	if errors.Is(err, fs.ErrNotExist) {
+		issues.add(o.lookup(path...), "模板包 %s 未安装，执行 gsus template pack install 安装", pack)
+		return
+	}
+	if err != nil {
+		issues.add(o.lookup(path...), "%s", err)
+		return
+	}
+	if !m.HasTemplate(name) {
+		issues.add(o.lookup(path...), "模板包 %s 不提供模板 %s，可选: %s", pack, name, strings.Join(m.Templates, ", "))
+		return
+	}
+	if missing := o.MissingKeys(m.Config); len(missing) > 0 {
+		issues.add(o.lookup(path...), "模板包 %s 需要设置配置项: %s", pack, strings.Join(missing, ", "))
+	}
+}
+
+// checkPathTemplate method    检查路径模板能否解析.
+func (o *Option) checkPathTemplate(issues *configIssues, text string, path ...any) {
+	if len(text) == 0 {
+		return
+	}
+	if _, err := utils.NewTemplate("path").Parse(text); err != nil {
+		issues.add(o.lookup(path...), "路径模板解析失败: %s", err)
+	}
+}
@@ -0,0 +1,199 @@
+package config
+
+import (
+	"strings"
+	"testing"
+)
+
+// TestParse function    测试严格解析配置及错误位置.
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		wantErr []string // 错误信息应包含的内容，为空表示解析成功
+	}{
+		{
+			name: "合法配置",
+			content: `db2struct:
+  type: mysql
+  port: 3306
+`,
+		},
+		{
+			name: "未知配置项",
+			content: `db2struct:
+  type: mysql
+  hots: localhost
+`,
+			wantErr: []string{GsusConfigFile + ":3:3: 未知配置项 db2struct.hots", "host"},
+		},
+		{
+			name:    "未知顶层配置项",
+			content: "unknown: 1\n",
+			wantErr: []string{GsusConfigFile + ":1:1: 未知配置项 unknown"},
+		},
+		{
+			name: "类型不匹配",
+			content: `db2struct:
+  port: abc
+`,
+			wantErr: []string{GsusConfigFile + ":2:9: db2struct.port:", "abc"},
+		},
+		{
+			name: "映射类型不匹配",
+			content: `db2struct: mysql
+`,
+			wantErr: []string{GsusConfigFile + ":1:12: db2struct 应为映射"},
+		},
+		{
+			name: "列表类型不匹配",
+			content: `impls:
+  name: service
+`,
+			wantErr: []string{GsusConfigFile + ":2:3: impls 应为列表"},
+		},
+		{
+			name: "多处错误",
+			content: `db2struct:
+  port: abc
+  hots: localhost
+`,
+			wantErr: []string{GsusConfigFile + ":2:9: ", GsusConfigFile + ":3:3: "},
+		},
+		{
+			name: "锚点所在的未知配置项",
+			content: `defaults: &scope
+  scope: service
+enum: *scope
+`,
+			wantErr: []string{"未知配置项 defaults"},
+		},
+		{
+			name: "合并键",
+			content: `impls:
+  - &impl
+    name: service
+    scope: service
+  - <<: *impl
+    name: dao
+`,
+		},
+		{
+			name: "合并键中的未知配置项",
+			content: `impls:
+  - &impl
+    name: service
+    scopes: service
+  - <<: *impl
+    name: dao
+`,
+			wantErr: []string{GsusConfigFile + ":4:5: 未知配置项 impls[0].scopes"},
+		},
+		{
+			name: "别名引用的值类型不匹配",
+			content: `db2struct:
+  genericMapTypes: &list [a, b]
+  host: *list
+`,
+			wantErr: []string{GsusConfigFile + ":2:20: db2struct.host 应为单个值"},
+		},
+		{
+			name: "已废弃的配置项",
+			content: `impls:
+  - name: service
+    pkgPrefix: svc
+mounts:
+  - name: config
+    template: ""
+    passive: false
+`,
+		},
+		{
+			name: "语义校验",
+			content: `db2struct:
+  type: oracle
+`,
+			wantErr: []string{GsusConfigFile + ":2:9: 不支持的数据库类型"},
+		},
+		{
+			name:    "yaml 语法错误",
+			content: "db2struct:\n  type: [mysql\n",
+			wantErr: []string{GsusConfigFile + ":"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := parse("", configSource{Path: GsusConfigFile, Content: []byte(tt.content)})
+			if len(tt.wantErr) == 0 {
+				if err != nil {
+					t.Errorf("parse() error = %v, want nil", err)
+				}
+				return
+			}
+			if err == nil {
+				t.Fatalf("parse() error = nil, want %v", tt.wantErr)
+			}
+			for _, want := range tt.wantErr {
+				if !strings.Contains(err.Error(), want) {
+					t.Errorf("parse() error = %v, want contains %q", err, want)
+				}
+			}
+		})
+	}
+}
+
+// TestParse_Alias function    测试别名及合并键的值.
+func TestParse_Alias(t *testing.T) {
+	content := `impls:
+  - &impl
+    name: service
+    scope: service
+    structName: Service
+  - <<: *impl
+    name: dao
+`
+	o, err := parse("", configSource{Path: GsusConfigFile, Content: []byte(content)})
+	if err != nil {
+		t.Fatalf("parse() error = %v", err)
+	}
+	if len(o.Impls) != 2 {
+		t.Fatalf("parse() impls = %v, want 2 items", o.Impls)
+	}
+	if o.Impls[1].Name != "dao" || o.Impls[1].Scope != "service" || o.Impls[1].StructName != "Service" {
+		t.Errorf("parse() impls[1] = %+v, want merged from impls[0]", o.Impls[1])
+	}
+}
+
+// TestConfigIssue_String function    测试配置问题的位置格式.
+func TestConfigIssue_String(t *testing.T) {
+	tests := []struct {
+		name  string
+		issue configIssue
+		want  string
+	}{
+		{
+			name:  "行列号",
+			issue: configIssue{File: "config.yaml", Line: 3, Column: 5, Message: "错误"},
+			want:  "config.yaml:3:5: 错误",
+		},
+		{
+			name:  "仅行号",
+			issue: configIssue{File: "config.yaml", Line: 3, Message: "错误"},
+			want:  "config.yaml:3: 错误",
+		},
+		{
+			name:  "无位置",
+			issue: configIssue{File: "config.yaml", Message: "错误"},
+			want:  "config.yaml: 错误",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.issue.String(); got != tt.want {
+				t.Errorf("configIssue.String() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
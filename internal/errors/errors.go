@@ -103,8 +103,9 @@ func Is(err error, target error) bool {
 	return errors.Is(err, target)
 }
 
+// As function    在错误链中查找与 target 匹配的错误，target 须为非空指针.
 func As(err error, target any) bool {
-	return errors.As(err, &target)
+	return errors.As(err, target)
 }
 
 // HasCode function    判断错误是否包含指定错误码.
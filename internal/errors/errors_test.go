@@ -2,6 +2,7 @@ package errors
 
 import (
 	"errors"
+	"fmt"
 	"testing"
 )
 
@@ -192,6 +193,66 @@ func TestNewf(t *testing.T) {
 	}
 }
 
+// testError 测试用的自定义错误类型.
+type testError struct {
+	msg string
+}
+
+func (e *testError) Error() string { return e.msg }
+
+// TestAs function    测试在错误链中查找指定类型的错误.
+func TestAs(t *testing.T) {
+	target := &testError{msg: "目标错误"}
+	tests := []struct {
+		name    string
+		err     error
+		wantOk  bool
+		wantErr *testError
+	}{
+		{
+			name:    "错误本身",
+			err:     target,
+			wantOk:  true,
+			wantErr: target,
+		},
+		{
+			name:    "包装在错误链中",
+			err:     WrapWithCode(fmt.Errorf("包装: %w", target), ErrCodeFile, "文件错误"),
+			wantOk:  true,
+			wantErr: target,
+		},
+		{
+			name:   "错误链中不存在",
+			err:    New(ErrCodeFile, "文件错误"),
+			wantOk: false,
+		},
+		{
+			name:   "空错误",
+			err:    nil,
+			wantOk: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got *testError
+			if ok := As(tt.err, &got); ok != tt.wantOk {
+				t.Errorf("As() = %v, want %v", ok, tt.wantOk)
+			}
+			if got != tt.wantErr {
+				t.Errorf("As() target = %v, want %v", got, tt.wantErr)
+			}
+		})
+	}
+
+	t.Run("查找 *Error", func(t *testing.T) {
+		var e *Error
+		if !As(fmt.Errorf("包装: %w", New(ErrCodeParse, "解析失败")), &e) || e.Code != ErrCodeParse {
+			t.Errorf("As() target = %v, want code %v", e, ErrCodeParse)
+		}
+	})
+}
+
 // TestHasCode function    测试错误码判断.
 func TestHasCode(t *testing.T) {
 	tests := []struct {
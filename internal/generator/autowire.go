@@ -0,0 +1,596 @@
+package generator
+
+import (
+	"fmt"
+	"go/ast"
+	goparser "go/parser"
+	"go/token"
+	"os"
+	"os/exec"
+	"path"
+	"path/filepath"
+	"reflect"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"text/template"
+
+	"github.com/spelens-gud/gsus/internal/errors"
+	"github.com/spelens-gud/gsus/internal/logger"
+	template2 "github.com/spelens-gud/gsus/internal/template"
+	"github.com/spelens-gud/gsus/internal/utils"
+	"github.com/stoewer/go-strcase"
+)
+
+var defaultAutowireTemplate = template.Must(utils.NewTemplate("autowire").Parse(template2.DefaultAutowireTemplate))
+
+var autowireAnnotateRegex = regexp.MustCompile(`@autowire(?:\((.*?)\))?`)
+
+const (
+	// defaultAutowireSet 未指定 set 时的默认依赖集合.
+	defaultAutowireSet = "default"
+	// autowireFileName 依赖注入代码文件名.
+	autowireFileName = "autowire_gen.go"
+)
+
+// AutowireConfig struct    依赖注入生成配置.
+type AutowireConfig struct {
+	Scope    string             // 扫描范围
+	Path     string             // 注入代码输出目录
+	Template *template.Template // 注入代码模板
+}
+
+// AutowireFile struct    依赖注入代码文件，作为模板的渲染数据.
+type AutowireFile struct {
+	Package   string             // 包名
+	Imports   []AutowireImport   // 导入列表
+	Sets      []AutowireSet      // 依赖集合
+	Providers []AutowireProvider // 构造函数
+}
+
+// AutowireImport struct    导入的包.
+type AutowireImport struct {
+	Alias string // 包别名
+	Path  string // 导入路径
+}
+
+// AutowireSet struct    依赖集合，对应一个 Initialize 函数.
+type AutowireSet struct {
+	Name     string             // 集合名称
+	TypeName string             // 集合结构体名
+	FuncName string             // 初始化函数名
+	Params   []AutowireParam    // 外部依赖参数
+	Steps    []AutowireStep     // 按依赖顺序排列的构造步骤
+	Fields   []AutowireSetField // 集合结构体字段
+}
+
+// AutowireParam struct    函数参数.
+type AutowireParam struct {
+	Name string // 参数名
+	Type string // 参数类型
+}
+
+// AutowireStep struct    初始化函数中的一次构造调用.
+type AutowireStep struct {
+	Var  string   // 变量名
+	Ctor string   // 构造函数名
+	Args []string // 调用参数
+}
+
+// AutowireSetField struct    依赖集合结构体字段.
+type AutowireSetField struct {
+	Name string // 字段名
+	Type string // 字段类型
+	Var  string // 对应的构造变量
+}
+
+// AutowireProvider struct    @autowire 结构体的构造函数.
+type AutowireProvider struct {
+	Name   string          // 构造函数名
+	Type   string          // 结构体类型
+	Params []AutowireParam // 构造参数
+	Fields []AutowireField // 字段赋值
+}
+
+// AutowireField struct    构造函数中的字段赋值.
+type AutowireField struct {
+	Field string // 字段名
+	Value string // 参数名
+}
+
+// autowireType struct    依赖类型引用.
+type autowireType struct {
+	Path    string // 导入路径
+	Pkg     string // 包名
+	Name    string // 类型名
+	Pointer bool   // 是否为指针
+}
+
+// key method    返回类型的唯一标识.
+func (t autowireType) key() string {
+	if t.Pointer {
+		return "*" + t.Path + "." + t.Name
+	}
+	return t.Path + "." + t.Name
+}
+
+// autowireProvider struct    带 @autowire 注解的结构体.
+type autowireProvider struct {
+	Decl     *typeDecl     // 结构体定义
+	Provides autowireType  // 提供的类型，未指定接口时为结构体指针
+	Set      string        // 所属依赖集合
+	Deps     []autowireDep // 需要注入的字段
+	self     autowireType  // 结构体自身类型
+	fields   []autowireDep // 候选字段，解析完成后筛选为 Deps
+}
+
+// autowireDep struct    结构体中需要注入的字段.
+type autowireDep struct {
+	Field    string            // 字段名
+	Type     autowireType      // 字段类型
+	Provider *autowireProvider // 提供者，为空时作为外部依赖由初始化函数传入
+	Pos      string            // 字段所在的文件及行号
+}
+
+// autowireGraph struct    依赖关系图.
+type autowireGraph struct {
+	Providers  []*autowireProvider            // 所有提供者
+	byKey      map[string]*autowireProvider   // 类型标识 -> 提供者
+	duplicates map[string][]*autowireProvider // 类型标识 -> 重复的提供者
+	index      *typeIndex                     // 类型索引
+}
+
+// GenAutowire function    扫描 @autowire 注解的结构体并生成依赖注入代码.
+func GenAutowire(cfg AutowireConfig) (err error) {
+	if cfg.Template == nil {
+		cfg.Template = defaultAutowireTemplate
+	}
+
+	graph, err := buildAutowireGraph(cfg.Scope)
+	if err != nil {
+		return err
+	}
+	if len(graph.Providers) == 0 {
+		logger.Warn("未找到 @autowire 注解的结构体")
+		return nil
+	}
+	if err = graph.check(); err != nil {
+		return err
+	}
+
+	projectDir, err := utils.GetProjectDir()
+	if err != nil {
+		return errors.WrapWithCode(err, errors.ErrCodeConfig, fmt.Sprintf("获取项目目录失败: %s", err))
+	}
+	modBase, err := utils.GetModBase()
+	if err != nil {
+		return errors.WrapWithCode(err, errors.ErrCodeConfig, fmt.Sprintf("获取模块路径失败: %s", err))
+	}
+	rel, err := filepath.Rel(projectDir, cfg.Path)
+	if err != nil || strings.HasPrefix(rel, "..") {
+		return errors.New(errors.ErrCodeConfig, fmt.Sprintf("注入代码目录 %s 不在项目目录内", cfg.Path))
+	}
+	importPath := modBase
+	if rel != "." {
+		importPath = path.Join(modBase, filepath.ToSlash(rel))
+	}
+
+	file, err := graph.render(dirPackageName(cfg.Path), importPath)
+	if err != nil {
+		return err
+	}
+	data, err := utils.ExecuteTemplate(cfg.Template, file)
+	if err != nil {
+		return errors.WrapWithCode(err, errors.ErrCodeTemplate, fmt.Sprintf("渲染依赖注入代码失败: %s", err))
+	}
+	if err = os.MkdirAll(cfg.Path, 0o755); err != nil {
+		return errors.WrapWithCode(err, errors.ErrCodeFile, fmt.Sprintf("创建目录失败: %s", err))
+	}
+	fp := filepath.Join(cfg.Path, autowireFileName)
+	logger.Info("generating autowire [ %d providers ] in [ %s ]", len(file.Providers), fp)
+	if err = utils.ImportAndWrite(data, fp); err != nil {
+		return errors.WrapWithCode(err, errors.ErrCodeFile, fmt.Sprintf("写入依赖注入代码失败: %s", err))
+	}
+
+	// 编译检查生成的代码
+	command := exec.Command("go", "build", "./"+filepath.ToSlash(rel))
+	command.Dir = projectDir
+	if out, err := command.CombinedOutput(); err != nil {
+		return errors.New(errors.ErrCodeGenerate, fmt.Sprintf("依赖注入代码编译失败: %s\n%s", err, out))
+	}
+	return nil
+}
+
+// buildAutowireGraph function    扫描范围内的 @autowire 结构体并建立依赖关系图.
+func buildAutowireGraph(scope string) (*autowireGraph, error) {
+	index, err := newTypeIndex(scope)
+	if err != nil {
+		return nil, err
+	}
+	graph := &autowireGraph{
+		byKey:      make(map[string]*autowireProvider),
+		duplicates: make(map[string][]*autowireProvider),
+		index:      index,
+	}
+
+	paths := make([]string, 0, len(index.pkgs))
+	for p := range index.pkgs {
+		paths = append(paths, p)
+	}
+	sort.Strings(paths)
+	for _, p := range paths {
+		pkg := index.pkgs[p]
+		names := make([]string, 0, len(pkg.Types))
+		for name := range pkg.Types {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			provider, err := graph.parseProvider(pkg.Types[name])
+			if err != nil {
+				return nil, err
+			}
+			if provider == nil {
+				continue
+			}
+			// 重复的提供者记录后由依赖检查统一报告
+			key := provider.Provides.key()
+			if exist, ok := graph.byKey[key]; ok {
+				if len(graph.duplicates[key]) == 0 {
+					graph.duplicates[key] = []*autowireProvider{exist}
+				}
+				graph.duplicates[key] = append(graph.duplicates[key], provider)
+				continue
+			}
+			graph.byKey[key] = provider
+			graph.Providers = append(graph.Providers, provider)
+		}
+	}
+
+	// 所有提供者收集完成后再确定需要注入的字段
+	for _, provider := range graph.Providers {
+		for _, dep := range provider.fields {
+			if dep.Provider = graph.byKey[dep.Type.key()]; dep.Provider != nil || graph.isExternal(dep.Type) {
+				provider.Deps = append(provider.Deps, dep)
+			}
+		}
+	}
+	return graph, nil
+}
+
+// parseProvider method    解析带 @autowire 注解的结构体，未注解时返回 nil.
+func (g *autowireGraph) parseProvider(decl *typeDecl) (*autowireProvider, error) {
+	if decl.Doc == nil {
+		return nil, nil
+	}
+	match := autowireAnnotateRegex.FindStringSubmatch(decl.Doc.Text())
+	if match == nil {
+		return nil, nil
+	}
+	st, ok := decl.Spec.Type.(*ast.StructType)
+	if !ok {
+		logger.Warn("%s 不是结构体，忽略 @autowire 注解: %s", decl.Name, decl.position())
+		return nil, nil
+	}
+	if decl.Spec.TypeParams != nil {
+		logger.Warn("%s 为泛型结构体，忽略 @autowire 注解: %s", decl.Name, decl.position())
+		return nil, nil
+	}
+
+	args, kv, err := parseKV(match[1])
+	if err != nil {
+		return nil, errors.WrapWithCode(err, errors.ErrCodeParse, fmt.Sprintf("解析 %s 的 @autowire 注解失败: %s", decl.Name, err))
+	}
+	provider := &autowireProvider{
+		Decl: decl,
+		Set:  defaultAutowireSet,
+		self: autowireType{Path: decl.Pkg.Path, Pkg: decl.Pkg.Name, Name: decl.Name, Pointer: true},
+	}
+	provider.Provides = provider.self
+	for k, v := range kv {
+		if strings.TrimSpace(k) == "set" && len(strings.TrimSpace(v)) > 0 {
+			provider.Set = strings.TrimSpace(v)
+		}
+	}
+	if len(args) > 0 && len(strings.TrimSpace(args[0])) > 0 {
+		expr, err := goparser.ParseExpr(strings.TrimSpace(args[0]))
+		if err != nil {
+			return nil, errors.WrapWithCode(err, errors.ErrCodeParse, fmt.Sprintf("解析 %s 的 @autowire 接口失败: %s", decl.Name, err))
+		}
+		typ, ok := g.resolveType(expr, decl)
+		if !ok {
+			return nil, errors.New(errors.ErrCodeParse, fmt.Sprintf("无法解析 %s 的 @autowire 接口 %s: %s", decl.Name, args[0], decl.position()))
+		}
+		provider.Provides = typ
+	}
+
+	for _, field := range st.Fields.List {
+		if field.Tag != nil {
+			tag, _ := strconv.Unquote(field.Tag.Value)
+			if reflect.StructTag(tag).Get("autowire") == "-" {
+				continue
+			}
+		}
+		typ, ok := g.resolveType(field.Type, decl)
+		if !ok {
+			continue
+		}
+		pos := decl.Fset.Position(field.Pos())
+		names := field.Names
+		if len(names) == 0 {
+			names = []*ast.Ident{ast.NewIdent(embeddedName(field.Type))}
+		}
+		for _, name := range names {
+			if !name.IsExported() {
+				continue
+			}
+			provider.fields = append(provider.fields, autowireDep{
+				Field: name.Name,
+				Type:  typ,
+				Pos:   fmt.Sprintf("%s:%d", pos.Filename, pos.Line),
+			})
+		}
+	}
+	return provider, nil
+}
+
+// resolveType method    解析类型表达式引用的具名类型.
+func (g *autowireGraph) resolveType(expr ast.Expr, decl *typeDecl) (autowireType, bool) {
+	switch t := expr.(type) {
+	case *ast.StarExpr:
+		typ, ok := g.resolveType(t.X, decl)
+		if !ok || typ.Pointer {
+			return autowireType{}, false
+		}
+		typ.Pointer = true
+		return typ, true
+	case *ast.Ident:
+		if _, ok := decl.Pkg.Types[t.Name]; !ok {
+			return autowireType{}, false
+		}
+		return autowireType{Path: decl.Pkg.Path, Pkg: decl.Pkg.Name, Name: t.Name}, true
+	case *ast.SelectorExpr:
+		x, ok := t.X.(*ast.Ident)
+		if !ok {
+			return autowireType{}, false
+		}
+		for _, imp := range decl.File.Imports {
+			importPath, _ := strconv.Unquote(imp.Path.Value)
+			pkgName := path.Base(importPath)
+			if pkg := g.index.pkgs[importPath]; pkg != nil {
+				pkgName = pkg.Name
+			}
+			name := pkgName
+			if imp.Name != nil {
+				name = imp.Name.Name
+			}
+			if name == x.Name {
+				return autowireType{Path: importPath, Pkg: pkgName, Name: t.Sel.Name}, true
+			}
+		}
+	}
+	return autowireType{}, false
+}
+
+// isExternal method    判断没有提供者的类型是否作为外部依赖由初始化函数传入.
+// 项目内的接口及项目外（包括标准库）的类型作为外部依赖，项目内的其他类型不做注入.
+func (g *autowireGraph) isExternal(typ autowireType) bool {
+	if pkg := g.index.pkgs[typ.Path]; pkg != nil {
+		decl := pkg.Types[typ.Name]
+		if decl == nil || typ.Pointer {
+			return false
+		}
+		_, ok := decl.Spec.Type.(*ast.InterfaceType)
+		return ok
+	}
+	return true
+}
+
+// sets method    按名称排序返回依赖集合及其提供者.
+func (g *autowireGraph) sets() (names []string, sets map[string][]*autowireProvider) {
+	sets = make(map[string][]*autowireProvider)
+	for _, provider := range g.Providers {
+		if _, ok := sets[provider.Set]; !ok {
+			names = append(names, provider.Set)
+		}
+		sets[provider.Set] = append(sets[provider.Set], provider)
+	}
+	sort.Strings(names)
+	return
+}
+
+// order method    按依赖顺序排列 roots 及其传递依赖，存在循环依赖时返回错误.
+func (g *autowireGraph) order(roots []*autowireProvider) ([]*autowireProvider, error) {
+	const (
+		visiting = 1
+		visited  = 2
+	)
+	var (
+		res   []*autowireProvider
+		stack []*autowireProvider
+		state = make(map[*autowireProvider]int)
+		visit func(p *autowireProvider) error
+	)
+	visit = func(p *autowireProvider) error {
+		switch state[p] {
+		case visited:
+			return nil
+		case visiting:
+			var cycle []string
+			for i := len(stack) - 1; i >= 0; i-- {
+				cycle = append([]string{stack[i].Decl.Name}, cycle...)
+				if stack[i] == p {
+					break
+				}
+			}
+			cycle = append(cycle, p.Decl.Name)
+			return errors.New(errors.ErrCodeGenerate, fmt.Sprintf("存在循环依赖: %s", strings.Join(cycle, " -> ")))
+		}
+		state[p] = visiting
+		stack = append(stack, p)
+		for _, dep := range p.Deps {
+			if dep.Provider == nil {
+				continue
+			}
+			if err := visit(dep.Provider); err != nil {
+				return err
+			}
+		}
+		stack = stack[:len(stack)-1]
+		state[p] = visited
+		res = append(res, p)
+		return nil
+	}
+	for _, p := range roots {
+		if err := visit(p); err != nil {
+			return nil, err
+		}
+	}
+	return res, nil
+}
+
+// render method    生成依赖注入代码的模板渲染数据.
+func (g *autowireGraph) render(pkgName, importPath string) (*AutowireFile, error) {
+	file := &AutowireFile{Package: pkgName}
+
+	// 分配导入别名
+	aliases := make(map[string]string)
+	used := map[string]bool{pkgName: true}
+	var imports []string
+	addImport := func(typ autowireType) {
+		if _, ok := aliases[typ.Path]; ok || typ.Path == importPath {
+			return
+		}
+		aliases[typ.Path] = ""
+		imports = append(imports, typ.Path)
+	}
+	for _, p := range g.Providers {
+		addImport(p.self)
+		addImport(p.Provides)
+		for _, dep := range p.Deps {
+			addImport(dep.Type)
+		}
+	}
+	sort.Strings(imports)
+	for _, p := range imports {
+		pkg := path.Base(p)
+		if idx := g.index.pkgs[p]; idx != nil {
+			pkg = idx.Name
+		}
+		alias := uniqueIdent(strings.NewReplacer("-", "_", ".", "_").Replace(pkg), used)
+		aliases[p] = alias
+		file.Imports = append(file.Imports, AutowireImport{Alias: alias, Path: p})
+	}
+	typeString := func(typ autowireType) string {
+		name := typ.Name
+		if alias := aliases[typ.Path]; len(alias) > 0 {
+			name = alias + "." + name
+		}
+		if typ.Pointer {
+			return "*" + name
+		}
+		return name
+	}
+	ident := func(typ autowireType) string {
+		if alias := aliases[typ.Path]; len(alias) > 0 {
+			return fmtFieldName(stringifyFirstChar(strcase.UpperCamelCase(alias))) + typ.Name
+		}
+		return typ.Name
+	}
+	// 构造函数及变量名不能与导入别名冲突
+	reserved := func() map[string]bool {
+		m := make(map[string]bool, len(aliases))
+		for _, alias := range aliases {
+			m[alias] = true
+		}
+		return m
+	}
+
+	names, sets := g.sets()
+	ctors := make(map[*autowireProvider]string)
+	var providers []*autowireProvider
+	for _, name := range names {
+		ordered, err := g.order(sets[name])
+		if err != nil {
+			return nil, err
+		}
+		set := AutowireSet{
+			Name:     name,
+			TypeName: fmtFieldName(stringifyFirstChar(strcase.UpperCamelCase(name))) + "Set",
+			FuncName: "Initialize" + fmtFieldName(stringifyFirstChar(strcase.UpperCamelCase(name))),
+		}
+		vars := make(map[*autowireProvider]string)
+		params := make(map[string]string)
+		scope := reserved()
+		for _, p := range ordered {
+			if _, ok := ctors[p]; !ok {
+				ctors[p] = uniqueIdent("New"+ident(p.self), used)
+				providers = append(providers, p)
+			}
+			step := AutowireStep{Ctor: ctors[p]}
+			for _, dep := range p.Deps {
+				if dep.Provider != nil {
+					step.Args = append(step.Args, vars[dep.Provider])
+					continue
+				}
+				key := dep.Type.key()
+				if _, ok := params[key]; !ok {
+					params[key] = uniqueIdent(strcase.LowerCamelCase(ident(dep.Type)), scope)
+					set.Params = append(set.Params, AutowireParam{Name: params[key], Type: typeString(dep.Type)})
+				}
+				step.Args = append(step.Args, params[key])
+			}
+			vars[p] = uniqueIdent(strcase.LowerCamelCase(ident(p.self)), scope)
+			step.Var = vars[p]
+			set.Steps = append(set.Steps, step)
+		}
+		fieldNames := make(map[string]bool)
+		for _, p := range sets[name] {
+			fieldName := p.Provides.Name
+			if fieldNames[fieldName] {
+				fieldName = uniqueIdent(ident(p.Provides), fieldNames)
+			}
+			fieldNames[fieldName] = true
+			set.Fields = append(set.Fields, AutowireSetField{Name: fieldName, Type: typeString(p.Provides), Var: vars[p]})
+		}
+		file.Sets = append(file.Sets, set)
+	}
+
+	for _, p := range providers {
+		provider := AutowireProvider{Name: ctors[p], Type: strings.TrimPrefix(typeString(p.self), "*")}
+		scope := reserved()
+		for _, dep := range p.Deps {
+			param := uniqueIdent(strcase.LowerCamelCase(dep.Field), scope)
+			provider.Params = append(provider.Params, AutowireParam{Name: param, Type: typeString(dep.Type)})
+			provider.Fields = append(provider.Fields, AutowireField{Field: dep.Field, Value: param})
+		}
+		file.Providers = append(file.Providers, provider)
+	}
+	return file, nil
+}
+
+// uniqueIdent function    返回未被使用的标识符，并记录到 used 中.
+func uniqueIdent(name string, used map[string]bool) string {
+	if len(name) == 0 {
+		name = "v"
+	}
+	ident := name
+	for i := 2; used[ident] || token.IsKeyword(ident) || isPredeclared(ident); i++ {
+		ident = name + strconv.Itoa(i)
+	}
+	used[ident] = true
+	return ident
+}
+
+// isPredeclared function    判断是否为预声明标识符.
+func isPredeclared(name string) bool {
+	switch name {
+	case "bool", "byte", "complex64", "complex128", "error", "float32", "float64", "int", "int8", "int16", "int32", "int64",
+		"rune", "string", "uint", "uint8", "uint16", "uint32", "uint64", "uintptr", "any", "comparable",
+		"true", "false", "iota", "nil", "append", "cap", "close", "complex", "copy", "delete", "imag", "len",
+		"make", "new", "panic", "print", "println", "real", "recover", "min", "max", "clear":
+		return true
+	}
+	return false
+}
@@ -0,0 +1,337 @@
+package generator
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/spelens-gud/gsus/internal/errors"
+	"github.com/spelens-gud/gsus/internal/logger"
+)
+
+const (
+	// AutowireGraphDot Graphviz DOT 格式.
+	AutowireGraphDot = "dot"
+	// AutowireGraphMermaid Mermaid 流程图格式.
+	AutowireGraphMermaid = "mermaid"
+)
+
+// AutowireGraphConfig struct    依赖关系图导出配置.
+type AutowireGraphConfig struct {
+	Scope  string // 扫描范围
+	Format string // 导出格式（dot/mermaid）
+}
+
+// autowireLevel type    依赖检查结果级别.
+type autowireLevel int
+
+const (
+	autowireInfo autowireLevel = iota
+	autowireWarning
+	autowireError
+)
+
+// autowireDiagnostic struct    依赖检查结果.
+type autowireDiagnostic struct {
+	Level   autowireLevel // 级别
+	Message string        // 描述，包含相关的文件及行号
+}
+
+// GenAutowireGraph function    扫描 @autowire 结构体并导出依赖关系图.
+// 依赖检查结果仅输出日志，存在循环依赖等错误时仍会导出，便于排查.
+func GenAutowireGraph(cfg AutowireGraphConfig) ([]byte, error) {
+	if len(cfg.Format) == 0 {
+		cfg.Format = AutowireGraphDot
+	}
+	if cfg.Format != AutowireGraphDot && cfg.Format != AutowireGraphMermaid {
+		return nil, errors.New(errors.ErrCodeConfig, fmt.Sprintf("不支持的依赖关系图格式: %s，可选 dot/mermaid", cfg.Format))
+	}
+
+	graph, err := buildAutowireGraph(cfg.Scope)
+	if err != nil {
+		return nil, err
+	}
+	graph.report(graph.diagnose())
+
+	if cfg.Format == AutowireGraphMermaid {
+		return graph.mermaid(), nil
+	}
+	return graph.dot(), nil
+}
+
+// check method    执行依赖检查并输出结果，存在错误时返回.
+func (g *autowireGraph) check() error {
+	var errs []string
+	diagnostics := g.diagnose()
+	for _, d := range diagnostics {
+		if d.Level == autowireError {
+			errs = append(errs, d.Message)
+		}
+	}
+	g.report(diagnostics)
+	if len(errs) > 0 {
+		return errors.New(errors.ErrCodeGenerate, fmt.Sprintf("依赖检查失败:\n%s", strings.Join(errs, "\n")))
+	}
+	return nil
+}
+
+// report method    按级别输出依赖检查结果.
+func (g *autowireGraph) report(diagnostics []autowireDiagnostic) {
+	for _, d := range diagnostics {
+		switch d.Level {
+		case autowireError:
+			logger.Error("%s", d.Message)
+		case autowireWarning:
+			logger.Warn("%s", d.Message)
+		default:
+			logger.Info("%s", d.Message)
+		}
+	}
+}
+
+// diagnose method    检查重复实现、循环依赖、缺少实现的接口、不会注入的字段及未被依赖的提供者.
+func (g *autowireGraph) diagnose() (diagnostics []autowireDiagnostic) {
+	// 同一类型存在多个实现
+	keys := make([]string, 0, len(g.duplicates))
+	for key := range g.duplicates {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		var providers []string
+		for _, p := range g.duplicates[key] {
+			providers = append(providers, fmt.Sprintf("%s (%s)", p.label(), p.Decl.position()))
+		}
+		diagnostics = append(diagnostics, autowireDiagnostic{
+			Level:   autowireError,
+			Message: fmt.Sprintf("%s 存在 %d 个 @autowire 实现: %s", typeLabel(g.duplicates[key][0].Provides), len(providers), strings.Join(providers, ", ")),
+		})
+	}
+
+	// 循环依赖
+	for _, cycle := range g.cycles() {
+		diagnostics = append(diagnostics, autowireDiagnostic{
+			Level:   autowireError,
+			Message: fmt.Sprintf("存在循环依赖: %s", cycle),
+		})
+	}
+
+	// 缺少实现的项目内接口，作为外部依赖传入
+	used := make(map[*autowireProvider]bool)
+	for _, p := range g.Providers {
+		for _, dep := range p.Deps {
+			if dep.Provider != nil {
+				used[dep.Provider] = true
+				continue
+			}
+			if g.isProjectInterface(dep.Type) {
+				diagnostics = append(diagnostics, autowireDiagnostic{
+					Level:   autowireWarning,
+					Message: fmt.Sprintf("%s.%s (%s) 依赖的接口 %s 没有 @autowire 实现，将由 Initialize 函数参数传入", p.label(), dep.Field, dep.Pos, typeLabel(dep.Type)),
+				})
+			}
+		}
+	}
+
+	// 没有提供者且不作为外部依赖的项目内类型，字段保持零值
+	for _, p := range g.Providers {
+		for _, dep := range p.fields {
+			if g.byKey[dep.Type.key()] == nil && !g.isExternal(dep.Type) {
+				diagnostics = append(diagnostics, autowireDiagnostic{
+					Level:   autowireWarning,
+					Message: fmt.Sprintf("%s.%s (%s) 的类型 %s 没有 @autowire 实现，不会注入，可使用 autowire:\"-\" 标签忽略", p.label(), dep.Field, dep.Pos, typeLabel(dep.Type)),
+				})
+			}
+		}
+	}
+
+	// 未被其他提供者依赖，仅通过依赖集合对外暴露
+	for _, p := range g.Providers {
+		if !used[p] {
+			diagnostics = append(diagnostics, autowireDiagnostic{
+				Level:   autowireInfo,
+				Message: fmt.Sprintf("%s (%s) 未被其他 @autowire 结构体依赖，仅通过 %s 依赖集合暴露", p.label(), p.Decl.position(), p.Set),
+			})
+		}
+	}
+	return
+}
+
+// cycles method    查找所有循环依赖，返回包含字段位置的完整路径.
+func (g *autowireGraph) cycles() (cycles []string) {
+	const (
+		visiting = 1
+		visited  = 2
+	)
+	type frame struct {
+		provider *autowireProvider
+		dep      autowireDep
+	}
+	var (
+		stack []frame
+		seen  = make(map[string]bool)
+		state = make(map[*autowireProvider]int)
+		visit func(p *autowireProvider)
+	)
+	visit = func(p *autowireProvider) {
+		state[p] = visiting
+		for _, dep := range p.Deps {
+			if dep.Provider == nil {
+				continue
+			}
+			stack = append(stack, frame{provider: p, dep: dep})
+			switch state[dep.Provider] {
+			case visiting:
+				start := len(stack) - 1
+				for stack[start].provider != dep.Provider {
+					start--
+				}
+				var (
+					hops []string
+					ids  []string
+				)
+				for _, f := range stack[start:] {
+					hops = append(hops, fmt.Sprintf("%s.%s (%s)", f.provider.label(), f.dep.Field, f.dep.Pos))
+					ids = append(ids, f.provider.self.key()+"."+f.dep.Field)
+				}
+				// 同一个环只报告一次
+				sort.Strings(ids)
+				if id := strings.Join(ids, ","); !seen[id] {
+					seen[id] = true
+					cycles = append(cycles, strings.Join(append(hops, dep.Provider.label()), " -> "))
+				}
+			case 0:
+				visit(dep.Provider)
+			}
+			stack = stack[:len(stack)-1]
+		}
+		state[p] = visited
+	}
+	for _, p := range g.Providers {
+		if state[p] == 0 {
+			visit(p)
+		}
+	}
+	return
+}
+
+// isProjectInterface method    判断类型是否为项目内定义的接口.
+func (g *autowireGraph) isProjectInterface(typ autowireType) bool {
+	pkg := g.index.pkgs[typ.Path]
+	if pkg == nil || typ.Pointer {
+		return false
+	}
+	decl := pkg.Types[typ.Name]
+	if decl == nil {
+		return false
+	}
+	_, ok := decl.Spec.Type.(*ast.InterfaceType)
+	return ok
+}
+
+// label method    返回提供者结构体的展示名称.
+func (p *autowireProvider) label() string {
+	return p.self.Pkg + "." + p.self.Name
+}
+
+// typeLabel function    返回类型的展示名称.
+func typeLabel(typ autowireType) string {
+	label := typ.Pkg + "." + typ.Name
+	if typ.Pointer {
+		return "*" + label
+	}
+	return label
+}
+
+// graphExternals method    返回依赖关系图中的外部依赖，按类型标识排序.
+func (g *autowireGraph) graphExternals() (keys []string, types map[string]autowireType) {
+	types = make(map[string]autowireType)
+	for _, p := range g.Providers {
+		for _, dep := range p.Deps {
+			if dep.Provider != nil {
+				continue
+			}
+			if _, ok := types[dep.Type.key()]; !ok {
+				keys = append(keys, dep.Type.key())
+			}
+			types[dep.Type.key()] = dep.Type
+		}
+	}
+	sort.Strings(keys)
+	return
+}
+
+// nodeLabel method    返回提供者节点的标签，提供接口时附带接口名.
+func (p *autowireProvider) nodeLabel(sep string) string {
+	if p.Provides == p.self {
+		return p.label()
+	}
+	return p.label() + sep + typeLabel(p.Provides)
+}
+
+// dot method    导出 Graphviz DOT 格式的依赖关系图，按依赖集合分组.
+func (g *autowireGraph) dot() []byte {
+	var buf bytes.Buffer
+	buf.WriteString("digraph autowire {\n\trankdir=LR;\n\tnode [shape=box];\n")
+	names, sets := g.sets()
+	for _, name := range names {
+		fmt.Fprintf(&buf, "\tsubgraph %s {\n\t\tlabel=%s;\n", strconv.Quote("cluster_"+name), strconv.Quote(name))
+		for _, p := range sets[name] {
+			fmt.Fprintf(&buf, "\t\t%s [label=%s];\n", strconv.Quote(p.self.key()), strconv.Quote(p.nodeLabel("\n")))
+		}
+		buf.WriteString("\t}\n")
+	}
+	keys, externals := g.graphExternals()
+	for _, key := range keys {
+		fmt.Fprintf(&buf, "\t%s [label=%s, style=dashed];\n", strconv.Quote(key), strconv.Quote(typeLabel(externals[key])))
+	}
+	for _, p := range g.Providers {
+		for _, dep := range p.Deps {
+			if dep.Provider != nil {
+				fmt.Fprintf(&buf, "\t%s -> %s [label=%s];\n", strconv.Quote(p.self.key()), strconv.Quote(dep.Provider.self.key()), strconv.Quote(dep.Field))
+				continue
+			}
+			fmt.Fprintf(&buf, "\t%s -> %s [label=%s, style=dashed];\n", strconv.Quote(p.self.key()), strconv.Quote(dep.Type.key()), strconv.Quote(dep.Field))
+		}
+	}
+	buf.WriteString("}\n")
+	return buf.Bytes()
+}
+
+// mermaid method    导出 Mermaid 流程图格式的依赖关系图，按依赖集合分组.
+func (g *autowireGraph) mermaid() []byte {
+	var buf bytes.Buffer
+	buf.WriteString("flowchart LR\n")
+	ids := make(map[string]string)
+	nodeID := func(key string) string {
+		if _, ok := ids[key]; !ok {
+			ids[key] = "n" + strconv.Itoa(len(ids))
+		}
+		return ids[key]
+	}
+	names, sets := g.sets()
+	for i, name := range names {
+		fmt.Fprintf(&buf, "\tsubgraph set%d[%q]\n", i, name)
+		for _, p := range sets[name] {
+			fmt.Fprintf(&buf, "\t\t%s[%q]\n", nodeID(p.self.key()), p.nodeLabel("<br/>"))
+		}
+		buf.WriteString("\tend\n")
+	}
+	keys, externals := g.graphExternals()
+	for _, key := range keys {
+		fmt.Fprintf(&buf, "\t%s([%q])\n", nodeID(key), typeLabel(externals[key]))
+	}
+	for _, p := range g.Providers {
+		for _, dep := range p.Deps {
+			if dep.Provider != nil {
+				fmt.Fprintf(&buf, "\t%s -->|%s| %s\n", nodeID(p.self.key()), dep.Field, nodeID(dep.Provider.self.key()))
+				continue
+			}
+			fmt.Fprintf(&buf, "\t%s -.->|%s| %s\n", nodeID(p.self.key()), dep.Field, nodeID(dep.Type.key()))
+		}
+	}
+	return buf.Bytes()
+}
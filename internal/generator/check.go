@@ -0,0 +1,458 @@
+package generator
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/importer"
+	goparser "go/parser"
+	"go/scanner"
+	"go/token"
+	"go/types"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"text/template"
+
+	"github.com/spelens-gud/gsus/internal/errors"
+	"github.com/spelens-gud/gsus/internal/parser"
+	"github.com/spelens-gud/gsus/internal/utils"
+	"github.com/stoewer/go-strcase"
+)
+
+// TemplateKind type    模板类型，决定检查模板时使用的示例数据.
+type TemplateKind string
+
+const (
+	// KindTemplate gsus template 代码模板，数据为 Template.
+	KindTemplate TemplateKind = "template"
+	// KindImpl 接口实现结构体模板，数据为 implsSync.
+	KindImpl TemplateKind = "impl"
+	// KindImplPath 接口实现文件路径模板，数据为 Impl.
+	KindImplPath TemplateKind = "impl_path"
+	// KindRouter HTTP 路由模板，数据为 parser.ApiGroup.
+	KindRouter TemplateKind = "router"
+	// KindClientApi HTTP 客户端接口模板，数据为 clientGroup.
+	KindClientApi TemplateKind = "client_api"
+	// KindClientBase HTTP 客户端基础模板，无数据.
+	KindClientBase TemplateKind = "client_base"
+	// KindEnum 枚举方法模板，数据为 Enum.
+	KindEnum TemplateKind = "enum"
+	// KindAutowire 依赖注入模板，数据为 AutowireFile.
+	KindAutowire TemplateKind = "autowire"
+	// KindGeneric 模型通用方法模板，数据为 parser.T.
+	KindGeneric TemplateKind = "generic"
+)
+
+// SampleData function    返回模板类型对应的示例渲染数据，name 为 gsus template 模板名或接口实现集名称.
+func SampleData(kind TemplateKind, name string) any {
+	switch kind {
+	case KindTemplate:
+		structName := strcase.UpperCamelCase(name)
+		fields := []Field{
+			newField("ID", "int64", "主键", `gorm:"column:id;primaryKey;autoIncrement;type:bigint;not null" json:"id"`),
+			newField("Name", "string", "名称", `gorm:"column:name;type:varchar(64);not null;default:'';index:idx_name" json:"name"`),
+			newField("DeletedAt", "sql.NullTime", "删除时间", `gorm:"column:deleted_at;type:datetime" json:"deleted_at"`),
+		}
+		return Template{
+			Name:           name,
+			ModelName:      "User",
+			ModelDesc:      "用户",
+			PackageName:    "user",
+			ServicePkgPath: "example.com/app/service",
+			ServicePkg:     "service",
+			ModelPkg:       "model",
+			StructName:     structName,
+			CallerIdent:    utils.GetFuncCallerIdent(structName),
+			Fields:         fields,
+			PrimaryKey:     primaryKey(fields),
+		}
+	case KindImpl:
+		return implsSync{
+			ImplStructName:       "UserImpl",
+			ImplPackage:          "svc_user",
+			InterfacePackagePath: "example.com/app/service",
+			InterfacePackageName: "service",
+			InterfaceName:        "User",
+			SetName:              name,
+		}
+	case KindImplPath:
+		return Impl{
+			InterfaceName:        "User",
+			InterfacePackageName: "service",
+			SnakeIfaceName:       "user",
+			ImplPackage:          "svc_user",
+			SetName:              name,
+			ImplStructName:       "UserImpl",
+		}
+	case KindRouter:
+		return sampleApiGroup()
+	case KindClientApi:
+		group := sampleApiGroup()
+		handlers := make(map[string]bool)
+		client := clientGroup{ApiGroup: *group}
+		for _, api := range group.Apis {
+			if c, ok := processApi(api, handlers); ok {
+				client.ClientApis = append(client.ClientApis, *c)
+			}
+		}
+		return &client
+	case KindEnum:
+		return Enum{
+			Package:     "model",
+			TypeName:    "Status",
+			SnakeName:   "status",
+			BaseType:    "int",
+			CallerIdent: "s",
+			Store:       "int",
+			Options:     map[string]string{},
+			Values: []EnumValue{
+				{Name: "StatusActive", Label: "active", Comment: "启用"},
+				{Name: "StatusDisabled", Label: "disabled", Comment: "禁用"},
+			},
+		}
+	case KindAutowire:
+		return AutowireFile{
+			Package: "autowire",
+			Imports: []AutowireImport{{Alias: "service", Path: "example.com/app/service"}},
+			Sets: []AutowireSet{{
+				Name:     "app",
+				TypeName: "App",
+				FuncName: "InitializeApp",
+				Params:   []AutowireParam{{Name: "db", Type: "*sql.DB"}},
+				Steps:    []AutowireStep{{Var: "userImpl", Ctor: "NewUserImpl", Args: []string{"db"}}},
+				Fields:   []AutowireSetField{{Name: "UserImpl", Type: "*UserImpl", Var: "userImpl"}},
+			}},
+			Providers: []AutowireProvider{{
+				Name:   "NewUserImpl",
+				Type:   "UserImpl",
+				Params: []AutowireParam{{Name: "db", Type: "*sql.DB"}},
+				Fields: []AutowireField{{Field: "DB", Value: "db"}},
+			}},
+		}
+	case KindGeneric:
+		return parser.T{
+			Type:     "User",
+			Package:  "model",
+			MapTypes: []parser.MapType{{MapType: "Int", MapBType: "int"}, {MapType: "String", MapBType: "string"}},
+		}
+	}
+	return struct{}{}
+}
+
+// sampleApiGroup function    返回示例 HTTP 接口组.
+func sampleApiGroup() *parser.ApiGroup {
+	return &parser.ApiGroup{
+		Package:     "api",
+		GroupName:   "User",
+		ServiceName: "service.User",
+		GroupRoute:  `"user"`,
+		Hash:        "d41d8cd98f00b204e9800998ecf8427e",
+		Options:     map[string]string{},
+		Filepath:    "api/user.go",
+		Apis: []*parser.Api{{
+			Params:     []string{"context.Context", "*model.GetUserReq"},
+			Returns:    []string{"*model.User", "error"},
+			Method:     "GetUser",
+			BaseRoute:  "/get",
+			HttpMethod: "GET",
+			Route:      `"/user/get"`,
+			Handler:    "GetUser",
+			Title:      "获取用户",
+			Options:    map[string]string{},
+			Doc:        []string{"GetUser 获取用户"},
+		}},
+	}
+}
+
+// CheckTemplate function    使用示例数据渲染模板并检查生成的 Go 代码能否通过编译，返回检查的代码.
+// 通用方法模板的渲染结果不包含 package 声明，返回的代码在首行补充了 package 声明.
+func CheckTemplate(kind TemplateKind, name string, tmpl *template.Template) ([]byte, error) {
+	var bf bytes.Buffer
+	data := SampleData(kind, name)
+	if err := tmpl.Execute(&bf, data); err != nil {
+		return nil, err
+	}
+	src := bf.Bytes()
+	if t, ok := data.(parser.T); ok {
+		// 通用方法追加在模型文件末尾，不包含 package 声明，补在首行以保持行号不变
+		src = append([]byte("package "+t.Package+"; "), src...)
+	}
+	return src, CheckGoSource(src, sampleStub(data))
+}
+
+// CheckTemplateFile function    使用示例数据渲染 gsus template 模板及输出路径，输出路径为 .go 文件时检查生成的代码.
+func CheckTemplateFile(temp TemplateFile) error {
+	return CheckDataTemplateFile(temp, SampleData(KindTemplate, temp.Name))
+}
+
+// CheckDataTemplateFile function    使用指定数据渲染模板及输出路径，输出路径为空时按 .go 文件检查生成的代码.
+func CheckDataTemplateFile(temp TemplateFile, data any) error {
+	fp := ".go"
+	if len(temp.Path) > 0 {
+		var err error
+		if fp, err = renderPath(temp.Path, data); err != nil {
+			return err
+		}
+	}
+	var bf bytes.Buffer
+	if err := temp.Content.Execute(&bf, data); err != nil {
+		return err
+	}
+	if filepath.Ext(fp) != ".go" {
+		return nil
+	}
+	return CheckGoSource(bf.Bytes(), sampleStub(data))
+}
+
+// RenderPath function    使用示例数据渲染输出路径模板.
+func RenderPath(kind TemplateKind, name, text string) (string, error) {
+	return renderPath(text, SampleData(kind, name))
+}
+
+// renderPath function    使用指定数据渲染输出路径模板.
+func renderPath(text string, data any) (string, error) {
+	pathTemplate, err := utils.NewTemplate("path").Parse(text)
+	if err != nil {
+		return "", errors.WrapWithCode(err, errors.ErrCodeTemplate, fmt.Sprintf("解析输出路径失败: %s", err))
+	}
+	var bf bytes.Buffer
+	if err = pathTemplate.Execute(&bf, data); err != nil {
+		return "", errors.WrapWithCode(err, errors.ErrCodeTemplate, fmt.Sprintf("渲染输出路径失败: %s", err))
+	}
+	fp := strings.TrimSpace(bf.String())
+	if len(fp) == 0 {
+		return "", errors.New(errors.ErrCodeTemplate, "输出路径为空")
+	}
+	return fp, nil
+}
+
+// CheckGoSource function    检查生成的 Go 代码能否通过编译，错误信息附带生成代码的行号及内容.
+// 先进行语法解析，再按生成时的流程处理导入后进行类型检查，类型检查的行号为处理导入后的代码行号.
+// stub 为生成代码所在包中的其他声明（不含 package 声明），生成的代码中已声明的同名声明会被忽略.
+// 项目包及第三方包无法在检查时加载，引用这些包的成员或无法确定导入路径的包名不会报错.
+func CheckGoSource(src []byte, stub string) error {
+	if _, err := goparser.ParseFile(token.NewFileSet(), "", src, goparser.AllErrors); err != nil {
+		var list scanner.ErrorList
+		if !errors.As(err, &list) || len(list) == 0 {
+			return errors.WrapWithCode(err, errors.ErrCodeTemplate, fmt.Sprintf("生成的代码无法解析: %s", err))
+		}
+		return sourceError(src, "无法解析", list[0].Pos.Line, list[0].Msg, len(list)-1)
+	}
+
+	src, err := utils.ImportProcess(src)
+	if err != nil {
+		return errors.WrapWithCode(err, errors.ErrCodeTemplate, fmt.Sprintf("生成的代码无法处理导入: %s", err))
+	}
+	typeErrs := typeCheck(src, stub)
+	if len(typeErrs) == 0 {
+		return nil
+	}
+	first := typeErrs[0]
+	return sourceError(src, "无法编译", first.Fset.Position(first.Pos).Line, first.Msg, len(typeErrs)-1)
+}
+
+// sourceError function    返回附带生成代码行号及内容的错误.
+func sourceError(src []byte, reason string, line int, msg string, others int) error {
+	lines := strings.Split(string(src), "\n")
+	text := fmt.Sprintf("生成的代码第 %d 行%s: %s", line, reason, msg)
+	if line > 0 && line <= len(lines) {
+		text += fmt.Sprintf("\n\t%d | %s", line, strings.TrimRight(lines[line-1], " \t"))
+	}
+	if others > 0 {
+		text += fmt.Sprintf("\n\t另有 %d 处错误", others)
+	}
+	return errors.New(errors.ErrCodeTemplate, text)
+}
+
+// checkFilename 类型检查时生成代码的文件名.
+const checkFilename = "gsus_check.go"
+
+// undefinedRegexp 匹配类型检查中未定义标识符的错误，如 undefined: service.User.
+var undefinedRegexp = regexp.MustCompile(`^undefined: (\w+)(?:\.\w+)?$`)
+
+// typeCheck function    对生成的代码及同包声明进行类型检查，返回生成代码中的错误.
+func typeCheck(src []byte, stub string) (errs []types.Error) {
+	fset := token.NewFileSet()
+	file, err := goparser.ParseFile(fset, checkFilename, src, goparser.AllErrors)
+	if err != nil {
+		return nil
+	}
+	files := []*ast.File{file}
+	if len(strings.TrimSpace(stub)) > 0 {
+		stubFile, err := goparser.ParseFile(fset, "gsus_stub.go", "package "+file.Name.Name+"\n"+stub, 0)
+		if err == nil {
+			pruneDecls(stubFile, declaredNames(file))
+			files = append(files, stubFile)
+		}
+	}
+
+	// 引用项目包及第三方包的包名，以及未导入的包名
+	external := make(map[string]bool)
+	for _, imp := range file.Imports {
+		importPath, _ := strconv.Unquote(imp.Path.Value)
+		if isStdPackage(importPath) {
+			continue
+		}
+		name := utils.ImportAlias(importPath)
+		if imp.Name != nil {
+			name = imp.Name.Name
+		}
+		external[name] = true
+	}
+	qualifiers := make(map[token.Pos]bool)
+	ast.Inspect(file, func(n ast.Node) bool {
+		if sel, ok := n.(*ast.SelectorExpr); ok {
+			if x, ok := sel.X.(*ast.Ident); ok {
+				qualifiers[x.Pos()] = true
+			}
+		}
+		return true
+	})
+
+	conf := types.Config{
+		Importer: checkImporter{},
+		Error: func(err error) {
+			typeErr, ok := err.(types.Error)
+			if !ok || typeErr.Fset.Position(typeErr.Pos).Filename != checkFilename {
+				return
+			}
+			if m := undefinedRegexp.FindStringSubmatch(typeErr.Msg); m != nil && (external[m[1]] || qualifiers[typeErr.Pos]) {
+				return
+			}
+			errs = append(errs, typeErr)
+		},
+	}
+	_, _ = conf.Check(file.Name.Name, fset, files, nil)
+	return errs
+}
+
+var (
+	stdImporterOnce sync.Once
+	stdImporter     types.Importer
+)
+
+// checkImporter struct    类型检查使用的导入器，标准库从源码加载，其他包返回空包.
+type checkImporter struct{}
+
+// Import method    导入包.
+func (checkImporter) Import(importPath string) (*types.Package, error) {
+	if isStdPackage(importPath) {
+		stdImporterOnce.Do(func() {
+			stdImporter = importer.ForCompiler(token.NewFileSet(), "source", nil)
+		})
+		return stdImporter.Import(importPath)
+	}
+	pkg := types.NewPackage(importPath, utils.ImportAlias(importPath))
+	pkg.MarkComplete()
+	return pkg, nil
+}
+
+// isStdPackage function    判断是否为标准库包，标准库导入路径的首段不包含点号.
+func isStdPackage(importPath string) bool {
+	first, _, _ := strings.Cut(importPath, "/")
+	return !strings.Contains(first, ".")
+}
+
+// declaredNames function    返回文件中的顶层声明名称，不包含方法.
+func declaredNames(file *ast.File) map[string]bool {
+	names := make(map[string]bool)
+	for _, decl := range file.Decls {
+		switch d := decl.(type) {
+		case *ast.FuncDecl:
+			if d.Recv == nil {
+				names[d.Name.Name] = true
+			}
+		case *ast.GenDecl:
+			for _, spec := range d.Specs {
+				switch sp := spec.(type) {
+				case *ast.TypeSpec:
+					names[sp.Name.Name] = true
+				case *ast.ValueSpec:
+					for _, name := range sp.Names {
+						names[name.Name] = true
+					}
+				}
+			}
+		}
+	}
+	return names
+}
+
+// pruneDecls function    删除文件中与 declared 同名的类型、常量及变量声明.
+func pruneDecls(file *ast.File, declared map[string]bool) {
+	decls := file.Decls[:0]
+	for _, decl := range file.Decls {
+		d, ok := decl.(*ast.GenDecl)
+		if !ok {
+			decls = append(decls, decl)
+			continue
+		}
+		specs := d.Specs[:0]
+		for _, spec := range d.Specs {
+			switch sp := spec.(type) {
+			case *ast.TypeSpec:
+				if declared[sp.Name.Name] {
+					continue
+				}
+			case *ast.ValueSpec:
+				if slices.ContainsFunc(sp.Names, func(name *ast.Ident) bool { return declared[name.Name] }) {
+					continue
+				}
+			}
+			specs = append(specs, spec)
+		}
+		if d.Specs = specs; len(specs) > 0 {
+			decls = append(decls, d)
+		}
+	}
+	file.Decls = decls
+}
+
+// sampleStub function    返回示例数据对应的同包声明，如枚举类型及模型结构体，用于检查引用这些声明的生成代码.
+func sampleStub(data any) string {
+	var bf strings.Builder
+	bf.WriteString("import (\n\t\"database/sql\"\n\t\"time\"\n)\n\nvar _ sql.NullTime\nvar _ time.Time\n\n")
+	switch d := data.(type) {
+	case Template:
+		fmt.Fprintf(&bf, "type %s struct {\n", d.ModelName)
+		for _, f := range d.Fields {
+			fmt.Fprintf(&bf, "\t%s %s\n", f.Name, f.Type)
+		}
+		bf.WriteString("}\n")
+	case Enum:
+		fmt.Fprintf(&bf, "type %s %s\n\nconst (\n", d.TypeName, d.BaseType)
+		for i, v := range d.Values {
+			value := strconv.Itoa(i)
+			if d.BaseType == "string" {
+				value = strconv.Quote(v.Label)
+			}
+			fmt.Fprintf(&bf, "\t%s %s = %s\n", v.Name, d.TypeName, value)
+		}
+		bf.WriteString(")\n")
+	case parser.T:
+		fmt.Fprintf(&bf, "type %s struct{}\n", d.Type)
+	case AutowireFile:
+		for _, p := range d.Providers {
+			params := make(map[string]string)
+			for _, param := range p.Params {
+				params[param.Name] = param.Type
+			}
+			fmt.Fprintf(&bf, "type %s struct {\n", p.Type)
+			for _, f := range p.Fields {
+				typ, ok := params[f.Value]
+				if !ok {
+					typ = "any"
+				}
+				fmt.Fprintf(&bf, "\t%s %s\n", f.Field, typ)
+			}
+			bf.WriteString("}\n")
+		}
+	default:
+		return ""
+	}
+	return bf.String()
+}
@@ -0,0 +1,87 @@
+package generator
+
+import (
+	"strings"
+	"testing"
+)
+
+// TestCheckGoSource function    测试生成代码的语法及类型检查.
+func TestCheckGoSource(t *testing.T) {
+	// 导入处理会查找当前模块的依赖，在模块外执行以免修改 go.sum
+	t.Chdir(t.TempDir())
+
+	tests := []struct {
+		name    string
+		src     string
+		stub    string
+		wantErr string // 错误信息应包含的内容，为空表示检查通过
+	}{
+		{
+			name: "合法代码",
+			src:  "package model\n\nfunc Hello() string {\n\treturn \"hello\"\n}\n",
+		},
+		{
+			name:    "语法错误",
+			src:     "package model\n\nfunc Hello() string {\n\treturn \"hello\"\n",
+			wantErr: "无法解析",
+		},
+		{
+			name:    "未定义标识符",
+			src:     "package model\n\nfunc Hello() string {\n\treturn hello\n}\n",
+			wantErr: "第 4 行无法编译: undefined: hello",
+		},
+		{
+			name:    "类型错误",
+			src:     "package model\n\nfunc Hello() string {\n\treturn 1\n}\n",
+			wantErr: "无法编译",
+		},
+		{
+			name: "缺少的标准库导入由导入处理补全",
+			src:  "package model\n\nfunc Hello() string {\n\treturn fmt.Sprint(1)\n}\n",
+		},
+		{
+			name:    "标准库包成员不存在",
+			src:     "package model\n\nimport \"fmt\"\n\nfunc Hello() string {\n\treturn fmt.Sprintff(1)\n}\n",
+			wantErr: "Sprintff",
+		},
+		{
+			name: "第三方包成员",
+			src:  "package model\n\nimport \"github.com/gin-gonic/gin\"\n\nfunc Hello(c *gin.Context) {\n\tc.JSON(200, nil)\n}\n",
+		},
+		{
+			name: "无法确定导入路径的包名",
+			src:  "package model\n\nvar _ service.User = nil\n",
+		},
+		{
+			name: "引用同包声明",
+			src:  "package model\n\nfunc (s Status) IsValid() bool {\n\treturn s == StatusActive\n}\n",
+			stub: "type Status int\n\nconst StatusActive Status = 0\n",
+		},
+		{
+			name: "同包声明被生成代码覆盖",
+			src:  "package model\n\ntype User struct {\n\tName string\n}\n\nvar _ = User{Name: \"a\"}\n",
+			stub: "type User struct{}\n",
+		},
+		{
+			name:    "同包声明的类型错误",
+			src:     "package model\n\nfunc (s Status) IsValid() bool {\n\treturn s == \"active\"\n}\n",
+			stub:    "type Status int\n",
+			wantErr: "无法编译",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := CheckGoSource([]byte(tt.src), tt.stub)
+			if len(tt.wantErr) == 0 {
+				if err != nil {
+					t.Errorf("CheckGoSource() error = %v, want nil", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("CheckGoSource() error = %v, want contains %q", err, tt.wantErr)
+			}
+		})
+	}
+}
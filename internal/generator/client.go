@@ -16,8 +16,8 @@ import (
 )
 
 var genFilePrefix = "client.go"
-var defaultApiTemplate = template.Must(template.New("api").Parse(tmpl.DefaultHttpClientApiTemplate))
-var defaultBaseTemplate = template.Must(template.New("base").Parse(tmpl.DefaultHttpClientBaseTemplate))
+var defaultApiTemplate = template.Must(utils.NewTemplate("api").Parse(tmpl.DefaultHttpClientApiTemplate))
+var defaultBaseTemplate = template.Must(utils.NewTemplate("base").Parse(tmpl.DefaultHttpClientBaseTemplate))
 
 // clientApi struct    HTTP 客户端 API 结构体.
 type clientApi struct {
@@ -0,0 +1,42 @@
+package generator
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/spelens-gud/gsus/internal/errors"
+	"gopkg.in/yaml.v3"
+)
+
+// LoadData function    读取 YAML 或 JSON 数据文件作为模板的渲染数据，.json 文件中的数字保留原始写法.
+func LoadData(path string) (data any, err error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, errors.WrapWithCode(err, errors.ErrCodeFile, fmt.Sprintf("读取数据文件失败: %s", path))
+	}
+	if strings.EqualFold(filepath.Ext(path), ".json") {
+		decoder := json.NewDecoder(bytes.NewReader(content))
+		decoder.UseNumber()
+		if err = decoder.Decode(&data); err != nil {
+			return nil, errors.WrapWithCode(err, errors.ErrCodeParse, fmt.Sprintf("解析数据文件 %s 失败: %s", path, err))
+		}
+		return data, nil
+	}
+	if err = yaml.Unmarshal(content, &data); err != nil {
+		return nil, errors.WrapWithCode(err, errors.ErrCodeParse, fmt.Sprintf("解析数据文件 %s 失败: %s", path, err))
+	}
+	return data, nil
+}
+
+// GenDataTemplate function    使用数据文件中的数据渲染模板及输出路径并写入文件.
+// 覆盖及 .bak 备份规则与模型模板相同.
+func GenDataTemplate(temp TemplateFile, data any) error {
+	if err := renderTemplateFile(temp, data); err != nil {
+		return errors.WrapWithCode(err, errors.ErrCodeTemplate, fmt.Sprintf("生成模板 %s 失败: %s", temp.Name, err))
+	}
+	return nil
+}
@@ -5,6 +5,7 @@ package db
 import (
 	"context"
 	"fmt"
+	"sort"
 
 	"github.com/spelens-gud/gsus/internal/errors"
 )
@@ -60,6 +61,16 @@ func (f *Adapter) Create(dbType Type) (IAdapter, error) {
 	return creator(), nil
 }
 
+// Types method    返回已注册的数据库类型，按名称排序.
+func (f *Adapter) Types() []Type {
+	types := make([]Type, 0, len(f.adapters))
+	for dbType := range f.adapters {
+		types = append(types, dbType)
+	}
+	sort.Slice(types, func(i, j int) bool { return types[i] < types[j] })
+	return types
+}
+
 // defaultAdapter var    默认工厂实例.
 var defaultAdapter = NewAdapter()
 
@@ -67,3 +78,8 @@ var defaultAdapter = NewAdapter()
 func GetAdapter(dbType Type) (IAdapter, error) {
 	return defaultAdapter.Create(dbType)
 }
+
+// Types function    返回默认工厂已注册的数据库类型.
+func Types() []Type {
+	return defaultAdapter.Types()
+}
@@ -38,15 +38,17 @@ type Table struct {
 
 // Column struct    列信息.
 type Column struct {
-	Name         string // 列名
-	Type         string // 数据库类型
-	GoType       string // Go类型
-	Nullable     bool   // 是否可空
-	IsPrimaryKey bool   // 是否主键
-	IsAutoIncr   bool   // 是否自增
-	Default      string // 默认值
-	Comment      string // 注释
-	Extra        string // 额外信息
+	Name         string   // 列名
+	Type         string   // 数据库类型
+	GoType       string   // Go类型
+	Nullable     bool     // 是否可空
+	IsPrimaryKey bool     // 是否主键
+	IsAutoIncr   bool     // 是否自增
+	Default      string   // 默认值
+	Comment      string   // 注释
+	Extra        string   // 额外信息
+	EnumValues   []string // 枚举可选值（MySQL ENUM/SET、PostgreSQL enum）
+	IsSet        bool     // 是否为集合类型（MySQL SET）
 }
 
 // Index struct    索引信息.
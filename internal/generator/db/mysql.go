@@ -143,12 +143,57 @@ func (a *MySQLAdapter) GetColumns(ctx context.Context, database, table string) (
 			column.Default = columnDefault.String
 		}
 
+		// 枚举与集合类型记录可选值
+		if dataType == "enum" || dataType == "set" {
+			column.EnumValues = parseMySQLEnumValues(columnType)
+			column.IsSet = dataType == "set"
+		}
+
 		columns = append(columns, column)
 	}
 
 	return columns, nil
 }
 
+// parseMySQLEnumValues function    解析 enum('a','b') 或 set('a','b') 形式列类型中的可选值.
+func parseMySQLEnumValues(columnType string) []string {
+	start := strings.Index(columnType, "(")
+	end := strings.LastIndex(columnType, ")")
+	if start < 0 || end <= start {
+		return nil
+	}
+
+	var (
+		values  []string
+		builder strings.Builder
+		quoted  bool
+	)
+	body := columnType[start+1 : end]
+	for i := 0; i < len(body); i++ {
+		c := body[i]
+		switch {
+		case !quoted:
+			if c == '\'' {
+				quoted = true
+				builder.Reset()
+			}
+		case c == '\\' && i+1 < len(body):
+			i++
+			builder.WriteByte(body[i])
+		case c == '\'' && i+1 < len(body) && body[i+1] == '\'':
+			// 两个连续单引号表示转义的单引号
+			i++
+			builder.WriteByte(c)
+		case c == '\'':
+			quoted = false
+			values = append(values, builder.String())
+		default:
+			builder.WriteByte(c)
+		}
+	}
+	return values
+}
+
 // GetIndexes method    获取MySQL表的所有索引.
 func (a *MySQLAdapter) GetIndexes(ctx context.Context, database, table string) ([]Index, error) {
 	query := `SELECT
@@ -12,6 +12,9 @@ import (
 	"github.com/spelens-gud/gsus/internal/errors"
 )
 
+// postgresSchema 读取表及枚举类型的模式名.
+const postgresSchema = "public"
+
 // PostgreSQLAdapter struct    PostgreSQL适配器.
 type PostgreSQLAdapter struct {
 	db *sql.DB
@@ -56,11 +59,11 @@ func (a *PostgreSQLAdapter) GetTables(ctx context.Context, database string, tabl
 		table_name,
 		COALESCE(obj_description((quote_ident(table_schema)||'.'||quote_ident(table_name))::regclass), '') as comment
 	FROM information_schema.tables
-	WHERE table_schema = 'public' AND table_type = 'BASE TABLE'`
+	WHERE table_schema = $1 AND table_type = 'BASE TABLE'`
 
-	args := []interface{}{}
+	args := []interface{}{postgresSchema}
 	if tableFilter != "" {
-		query += " AND table_name = $1"
+		query += " AND table_name = $2"
 		args = append(args, tableFilter)
 	}
 
@@ -79,7 +82,7 @@ func (a *PostgreSQLAdapter) GetTables(ctx context.Context, database string, tabl
 			return nil, errors.WrapWithCode(err, errors.ErrCodeDatabase,
 				fmt.Sprintf("扫描表信息失败: %s", err))
 		}
-		table.Schema = "public"
+		table.Schema = postgresSchema
 		tables = append(tables, table)
 	}
 
@@ -88,6 +91,11 @@ func (a *PostgreSQLAdapter) GetTables(ctx context.Context, database string, tabl
 
 // GetColumns method    获取PostgreSQL表的所有列.
 func (a *PostgreSQLAdapter) GetColumns(ctx context.Context, database, table string) ([]Column, error) {
+	enumMap, err := a.getEnumTypes(ctx)
+	if err != nil {
+		return nil, err
+	}
+
 	query := a.getColumnQuery()
 	rows, err := a.db.QueryContext(ctx, query, table)
 	if err != nil {
@@ -101,7 +109,7 @@ func (a *PostgreSQLAdapter) GetColumns(ctx context.Context, database, table stri
 	typeMap := a.TypeMapping()
 
 	for rows.Next() {
-		column, err := a.scanColumn(rows, typeMap)
+		column, err := a.scanColumn(rows, typeMap, enumMap)
 		if err != nil {
 			return nil, err
 		}
@@ -111,8 +119,39 @@ func (a *PostgreSQLAdapter) GetColumns(ctx context.Context, database, table stri
 	return columns, nil
 }
 
+// getEnumTypes method    获取表所在模式中的枚举类型及其可选值.
+func (a *PostgreSQLAdapter) getEnumTypes(ctx context.Context) (map[string][]string, error) {
+	// 仅读取表所在模式的枚举类型，避免其他模式中的同名类型覆盖
+	query := `SELECT t.typname, e.enumlabel
+	FROM pg_type t
+	JOIN pg_enum e ON t.oid = e.enumtypid
+	WHERE t.typnamespace = (SELECT oid FROM pg_namespace WHERE nspname = $1)
+	ORDER BY t.typname, e.enumsortorder`
+
+	rows, err := a.db.QueryContext(ctx, query, postgresSchema)
+	if err != nil {
+		return nil, errors.WrapWithCode(err, errors.ErrCodeDatabase,
+			fmt.Sprintf("查询枚举类型失败: %s", err))
+	}
+	//nolint:errcheck
+	defer rows.Close()
+
+	enumMap := make(map[string][]string)
+	for rows.Next() {
+		var typeName, label string
+		if err := rows.Scan(&typeName, &label); err != nil {
+			return nil, errors.WrapWithCode(err, errors.ErrCodeDatabase,
+				fmt.Sprintf("扫描枚举类型失败: %s", err))
+		}
+		enumMap[typeName] = append(enumMap[typeName], label)
+	}
+
+	return enumMap, nil
+}
+
 // scanColumn method    扫描单行列信息.
-func (a *PostgreSQLAdapter) scanColumn(rows *sql.Rows, typeMap map[string]string) (Column, error) {
+func (a *PostgreSQLAdapter) scanColumn(rows *sql.Rows, typeMap map[string]string,
+	enumMap map[string][]string) (Column, error) {
 	var (
 		columnName    string
 		dataType      string
@@ -138,6 +177,12 @@ func (a *PostgreSQLAdapter) scanColumn(rows *sql.Rows, typeMap map[string]string
 		Comment:      columnComment,
 	}
 
+	// 自定义枚举类型使用类型名作为数据库类型
+	if values, ok := enumMap[udtName]; ok && dataType == "USER-DEFINED" {
+		column.Type = udtName
+		column.EnumValues = values
+	}
+
 	if columnDefault.Valid {
 		column.Default = columnDefault.String
 		// 检查是否为序列（自增）
@@ -0,0 +1,126 @@
+package generator
+
+import (
+	"bytes"
+	"fmt"
+	"strconv"
+	"text/template"
+
+	"github.com/spelens-gud/gsus/internal/errors"
+	"github.com/spelens-gud/gsus/internal/generator/db"
+	template2 "github.com/spelens-gud/gsus/internal/template"
+	"github.com/spelens-gud/gsus/internal/utils"
+	"github.com/stoewer/go-strcase"
+)
+
+var columnEnumTemplate = template.Must(utils.NewTemplate("column_enum").Parse(
+	`{{ define "decl" }}` + template2.EnumDeclTemplate + `{{ end }}` +
+		`{{ define "methods" }}` + template2.EnumMethodsTemplate + `{{ end }}` +
+		`{{ define "set" }}` + template2.EnumSetTemplate + `{{ end }}` +
+		`{{ template "decl" . }}{{ template "methods" . }}{{ if .SetName }}{{ template "set" . }}{{ end }}`))
+
+// columnEnum struct    数据库枚举列对应的 Go 枚举类型.
+type columnEnum struct {
+	Enum
+	Comment        string // 类型注释
+	SetName        string // 集合类型名，仅 SET 列使用
+	SetCallerIdent string // 集合类型方法接收者名
+}
+
+// newColumnEnum function    根据枚举列创建 Go 枚举类型定义.
+// used 为已占用的类型名，SET 列的元素类型名与其冲突时追加序号.
+func newColumnEnum(structName, fieldName string, col db.Column, used map[string]bool) columnEnum {
+	typeName := structName + fieldName
+	e := columnEnum{
+		Comment: fmt.Sprintf("%s 列 %s 的枚举值.", structName, col.Name),
+	}
+	if col.IsSet {
+		e.SetName = typeName
+		e.SetCallerIdent = utils.GetFuncCallerIdent(typeName)
+		e.Comment = fmt.Sprintf("%s 的集合元素.", typeName)
+		typeName += "Item"
+		for i := 2; used[typeName]; i++ {
+			typeName = e.SetName + "Item" + strconv.Itoa(i)
+		}
+		used[typeName] = true
+	}
+
+	e.TypeName = typeName
+	e.SnakeName = strcase.SnakeCase(typeName)
+	e.BaseType = "string"
+	e.CallerIdent = utils.GetFuncCallerIdent(typeName)
+	e.Store = "string"
+
+	e.Values = enumConstValues(typeName, col.EnumValues)
+	return e
+}
+
+// enumConstValues function    根据枚举字符串生成常量定义，常量名为类型名加值的驼峰形式.
+func enumConstValues(typeName string, labels []string) (values []EnumValue) {
+	used := make(map[string]bool)
+	for _, label := range labels {
+		suffix := "Empty"
+		if len(label) > 0 {
+			suffix = fmtFieldName(stringifyFirstChar(strcase.UpperCamelCase(label)))
+		}
+		name := typeName + suffix
+		for i := 2; used[name]; i++ {
+			name = typeName + suffix + strconv.Itoa(i)
+		}
+		used[name] = true
+		values = append(values, EnumValue{Name: name, Label: label})
+	}
+	return
+}
+
+// GoType method    返回字段使用的 Go 类型，可空列使用指针类型.
+func (e columnEnum) GoType(nullable bool) string {
+	typ := e.TypeName
+	if len(e.SetName) > 0 {
+		typ = e.SetName
+	}
+	if nullable {
+		return "*" + typ
+	}
+	return typ
+}
+
+// resolveColumnEnums method    为枚举列生成枚举类型，并替换列的 Go 类型.
+func (g *Generator[T]) resolveColumnEnums(structName string, columns []db.Column) ([]db.Column, []columnEnum) {
+	if !g.opts.EnumType {
+		return columns, nil
+	}
+
+	// 先占用所有枚举列的类型名，避免 SET 列的元素类型与其他列（如 tags 与 tags_item）重名
+	used := make(map[string]bool)
+	for _, col := range columns {
+		if len(col.EnumValues) > 0 {
+			used[structName+fmtFieldName(stringifyFirstChar(col.Name))] = true
+		}
+	}
+
+	var enums []columnEnum
+	resolved := make([]db.Column, len(columns))
+	for i, col := range columns {
+		resolved[i] = col
+		if len(col.EnumValues) == 0 {
+			continue
+		}
+		e := newColumnEnum(structName, fmtFieldName(stringifyFirstChar(col.Name)), col, used)
+		resolved[i].GoType = e.GoType(col.Nullable)
+		enums = append(enums, e)
+	}
+	return resolved, enums
+}
+
+// generateEnumCode function    渲染枚举列对应的类型及方法代码.
+func generateEnumCode(enums []columnEnum) ([]byte, error) {
+	var buf bytes.Buffer
+	for _, e := range enums {
+		if err := columnEnumTemplate.Execute(&buf, e); err != nil {
+			return nil, errors.WrapWithCode(err, errors.ErrCodeTemplate,
+				fmt.Sprintf("生成枚举类型 %s 失败: %s", e.TypeName, err))
+		}
+	}
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,85 @@
+package generator
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/spelens-gud/gsus/internal/config"
+	"github.com/spelens-gud/gsus/internal/generator/db"
+)
+
+// TestResolveColumnEnums function    测试枚举列的类型命名.
+func TestResolveColumnEnums(t *testing.T) {
+	tests := []struct {
+		name      string
+		columns   []db.Column
+		wantTypes []string // 枚举类型名，SET 列格式为 集合类型名/元素类型名
+		wantGo    []string // 替换后的列 Go 类型
+	}{
+		{
+			name: "ENUM 及 SET 列",
+			columns: []db.Column{
+				{Name: "status", EnumValues: []string{"active", "disabled"}},
+				{Name: "tags", EnumValues: []string{"a", "b"}, IsSet: true, Nullable: true},
+			},
+			wantTypes: []string{"UserStatus", "UserTags/UserTagsItem"},
+			wantGo:    []string{"UserStatus", "*UserTags"},
+		},
+		{
+			name: "SET 元素类型与后续列重名",
+			columns: []db.Column{
+				{Name: "tags", EnumValues: []string{"a", "b"}, IsSet: true},
+				{Name: "tags_item", EnumValues: []string{"x"}},
+			},
+			wantTypes: []string{"UserTags/UserTagsItem2", "UserTagsItem"},
+			wantGo:    []string{"UserTags", "UserTagsItem"},
+		},
+		{
+			name: "SET 元素类型与前面的列重名",
+			columns: []db.Column{
+				{Name: "tags_item", EnumValues: []string{"x"}},
+				{Name: "tags_item2", EnumValues: []string{"y"}},
+				{Name: "tags", EnumValues: []string{"a", "b"}, IsSet: true},
+			},
+			wantTypes: []string{"UserTagsItem", "UserTagsItem2", "UserTags/UserTagsItem3"},
+			wantGo:    []string{"UserTagsItem", "UserTagsItem2", "UserTags"},
+		},
+		{
+			name: "非枚举列不生成类型",
+			columns: []db.Column{
+				{Name: "id", GoType: "int64"},
+				{Name: "tags_item", GoType: "string"},
+				{Name: "tags", EnumValues: []string{"a"}, IsSet: true},
+			},
+			wantTypes: []string{"UserTags/UserTagsItem"},
+			wantGo:    []string{"int64", "string", "UserTags"},
+		},
+	}
+
+	g := &Generator[*db.MySQLAdapter]{opts: &config.DbOpt{EnumType: true}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			columns, enums := g.resolveColumnEnums("User", tt.columns)
+
+			var types []string
+			for _, e := range enums {
+				if len(e.SetName) > 0 {
+					types = append(types, e.SetName+"/"+e.TypeName)
+				} else {
+					types = append(types, e.TypeName)
+				}
+			}
+			if strings.Join(types, ",") != strings.Join(tt.wantTypes, ",") {
+				t.Errorf("resolveColumnEnums() types = %v, want %v", types, tt.wantTypes)
+			}
+
+			var goTypes []string
+			for _, col := range columns {
+				goTypes = append(goTypes, col.GoType)
+			}
+			if strings.Join(goTypes, ",") != strings.Join(tt.wantGo, ",") {
+				t.Errorf("resolveColumnEnums() GoType = %v, want %v", goTypes, tt.wantGo)
+			}
+		})
+	}
+}
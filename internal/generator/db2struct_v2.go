@@ -103,6 +103,9 @@ func (g *Generator[T]) GenerateTable(ctx context.Context, tableName, outputDir s
 func (g *Generator[T]) generateStructCode(table db.Table, columns []db.Column, indexes []db.Index) ([]byte, error) {
 	structName := strcase.UpperCamelCase(table.Name)
 
+	// 解析枚举列
+	columns, enums := g.resolveColumnEnums(structName, columns)
+
 	// 生成字段定义
 	fields, fieldNameMap := g.generateFields(columns, indexes)
 
@@ -144,6 +147,15 @@ func (g *Generator[T]) generateStructCode(table db.Table, columns []db.Column, i
 		data = append(data, genericF...)
 	}
 
+	// 生成枚举类型
+	if len(enums) > 0 {
+		enumCode, err := generateEnumCode(enums)
+		if err != nil {
+			return nil, err
+		}
+		data = append(data, enumCode...)
+	}
+
 	return data, nil
 }
 
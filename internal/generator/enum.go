@@ -0,0 +1,299 @@
+package generator
+
+import (
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"text/template"
+
+	"github.com/spelens-gud/gsus/internal/errors"
+	"github.com/spelens-gud/gsus/internal/logger"
+	template2 "github.com/spelens-gud/gsus/internal/template"
+	"github.com/spelens-gud/gsus/internal/utils"
+	"github.com/stoewer/go-strcase"
+)
+
+var defaultEnumTemplate = template.Must(utils.NewTemplate("enum").Parse(template2.DefaultEnumTemplate))
+
+// defaultEnumPath 默认枚举代码输出文件名.
+const defaultEnumPath = "{{ .SnakeName }}_enum.go"
+
+var (
+	enumAnnotateRegex = regexp.MustCompile(`@enum(?:\((.*?)\))?`)
+	enumLabelRegex    = regexp.MustCompile(`@label\((.+?)\)`)
+)
+
+// EnumConfig struct    枚举生成配置.
+type EnumConfig struct {
+	Scope    string             // 扫描范围
+	Path     string             // 输出文件路径模板，相对路径基于枚举类型所在目录
+	Template *template.Template // 枚举代码模板
+}
+
+// Enum struct    枚举类型定义，作为枚举模板的渲染数据.
+type Enum struct {
+	Package     string            // 包名
+	TypeName    string            // 枚举类型名
+	SnakeName   string            // 蛇形类型名
+	BaseType    string            // 底层类型
+	CallerIdent string            // 方法接收者名
+	Store       string            // 数据库存储方式（string/int），为空时不生成 Scan/Value
+	Options     map[string]string // 注解选项
+	Values      []EnumValue       // 枚举值列表
+
+	dir string
+}
+
+// EnumValue struct    枚举值定义.
+type EnumValue struct {
+	Name    string // 常量名
+	Label   string // 字符串表示
+	Comment string // 注释
+}
+
+// GenEnums function    扫描 @enum 注解的常量块并生成枚举方法.
+func GenEnums(cfg EnumConfig) (err error) {
+	if len(cfg.Scope) == 0 {
+		cfg.Scope = "./"
+	}
+	if len(cfg.Path) == 0 {
+		cfg.Path = defaultEnumPath
+	}
+	if cfg.Template == nil {
+		cfg.Template = defaultEnumTemplate
+	}
+
+	pathTemplate, err := utils.NewTemplate("path").Parse(cfg.Path)
+	if err != nil {
+		return errors.WrapWithCode(err, errors.ErrCodeTemplate, fmt.Sprintf("解析枚举输出路径失败: %s", err))
+	}
+
+	enums, err := matchEnums(cfg.Scope)
+	if err != nil {
+		return errors.WrapWithCode(err, errors.ErrCodeParse, fmt.Sprintf("扫描枚举失败: %s", err))
+	}
+
+	for _, e := range enums {
+		path, err := utils.ExecuteTemplate(pathTemplate, e)
+		if err != nil {
+			return errors.WrapWithCode(err, errors.ErrCodeTemplate, fmt.Sprintf("渲染枚举输出路径失败: %s", err))
+		}
+		fp := string(path)
+		if !filepath.IsAbs(fp) {
+			fp = filepath.Join(e.dir, fp)
+		}
+		logger.Info("generating enum [ %s.%s ] in [ %s ]", e.Package, e.TypeName, fp)
+		if err = utils.ExecuteTemplateAndWrite(cfg.Template, e, fp); err != nil {
+			return errors.WrapWithCode(err, errors.ErrCodeGenerate, fmt.Sprintf("生成枚举 %s 失败: %s", e.TypeName, err))
+		}
+	}
+	return nil
+}
+
+// matchEnums function    在扫描范围内查找所有 @enum 注解的常量块.
+func matchEnums(scope string) (enums []Enum, err error) {
+	mu := sync.Mutex{}
+	err = utils.ExecFiles(scope, func(path string) (err error) {
+		data, err := os.ReadFile(path)
+		if err != nil || !enumAnnotateRegex.Match(data) {
+			return nil
+		}
+		// 解析时记录文件名，错误信息中报告常量块所在的文件及行号
+		fset := token.NewFileSet()
+		astFile, err := parser.ParseFile(fset, path, data, parser.ParseComments)
+		if err != nil {
+			return errors.WrapWithCode(err, errors.ErrCodeParse, fmt.Sprintf("解析文件失败: %s", err))
+		}
+		es, err := parseEnums(fset, astFile, filepath.Dir(path))
+		if err != nil {
+			return errors.WrapWithCode(err, errors.ErrCodeParse, fmt.Sprintf("解析文件 %s 枚举失败: %s", path, err))
+		}
+		mu.Lock()
+		enums = append(enums, es...)
+		mu.Unlock()
+		return nil
+	})
+	sort.Slice(enums, func(i, j int) bool {
+		return enums[i].dir+enums[i].TypeName < enums[j].dir+enums[j].TypeName
+	})
+	return
+}
+
+// parseEnums function    解析文件中带 @enum 注解的常量块.
+func parseEnums(fset *token.FileSet, astFile *ast.File, dir string) (enums []Enum, err error) {
+	for _, decl := range astFile.Decls {
+		genDecl, ok := decl.(*ast.GenDecl)
+		if !ok || genDecl.Tok != token.CONST || genDecl.Doc == nil {
+			continue
+		}
+
+		var match []string
+		for _, cm := range genDecl.Doc.List {
+			if match = enumAnnotateRegex.FindStringSubmatch(cm.Text); len(match) == 2 {
+				break
+			}
+		}
+		if len(match) == 0 {
+			continue
+		}
+
+		e, err := parseEnumDecl(fset, genDecl, astFile, dir)
+		if err != nil {
+			return nil, err
+		}
+		if len(match[1]) > 0 {
+			if _, e.Options, err = parseKV(match[1]); err != nil {
+				return nil, errors.WrapWithCode(err, errors.ErrCodeParse, fmt.Sprintf("解析枚举 %s 注解失败: %s", e.TypeName, err))
+			}
+		}
+		if err = e.parseStore(); err != nil {
+			return nil, err
+		}
+		enums = append(enums, e)
+	}
+	return
+}
+
+// parseEnumDecl function    解析单个枚举常量块.
+func parseEnumDecl(fset *token.FileSet, genDecl *ast.GenDecl, astFile *ast.File, dir string) (e Enum, err error) {
+	var typeName string
+	for _, spec := range genDecl.Specs {
+		vs := spec.(*ast.ValueSpec)
+		// 显式类型切换了枚举类型，其后的常量不再属于该枚举
+		if vs.Type != nil {
+			ident, ok := vs.Type.(*ast.Ident)
+			if !ok {
+				break
+			}
+			if len(typeName) == 0 {
+				typeName = ident.Name
+			} else if ident.Name != typeName {
+				break
+			}
+		} else if len(vs.Values) > 0 && len(typeName) > 0 {
+			// 无类型的显式赋值不属于该枚举
+			break
+		}
+		if len(typeName) == 0 {
+			continue
+		}
+
+		for i, name := range vs.Names {
+			if name.Name == "_" {
+				continue
+			}
+			v := EnumValue{
+				Name:  name.Name,
+				Label: strcase.SnakeCase(strings.TrimPrefix(name.Name, typeName)),
+			}
+			if i < len(vs.Values) {
+				if lit, ok := vs.Values[i].(*ast.BasicLit); ok && lit.Kind == token.STRING {
+					v.Label, _ = strconv.Unquote(lit.Value)
+				}
+			}
+			for _, cg := range []*ast.CommentGroup{vs.Doc, vs.Comment} {
+				if cg == nil {
+					continue
+				}
+				text := strings.TrimSpace(cg.Text())
+				if m := enumLabelRegex.FindStringSubmatch(text); len(m) == 2 {
+					v.Label = strings.Trim(m[1], `"`)
+					text = strings.TrimSpace(enumLabelRegex.ReplaceAllString(text, ""))
+				}
+				if len(text) > 0 {
+					v.Comment = text
+				}
+			}
+			e.Values = append(e.Values, v)
+		}
+	}
+
+	if len(typeName) == 0 || len(e.Values) == 0 {
+		pos := fset.Position(genDecl.Pos())
+		return e, errors.New(errors.ErrCodeParse,
+			fmt.Sprintf("@enum 常量块必须声明类型, 位于 %s:%d", pos.Filename, pos.Line))
+	}
+
+	e.Package = astFile.Name.Name
+	e.TypeName = typeName
+	e.SnakeName = strcase.SnakeCase(typeName)
+	e.CallerIdent = utils.GetFuncCallerIdent(typeName)
+	e.BaseType = lookupBaseType(typeName, astFile, dir)
+	e.dir = dir
+	return e, nil
+}
+
+// parseStore method    解析 store 选项，决定 Scan/Value 的存储方式.
+func (e *Enum) parseStore() error {
+	e.Store = strings.Trim(strings.TrimSpace(e.Options["store"]), `"`)
+	switch e.Store {
+	case "", "string":
+		return nil
+	case "int":
+		if !isIntegerType(e.BaseType) {
+			return errors.New(errors.ErrCodeParse,
+				fmt.Sprintf("枚举 %s 的底层类型 %s 不支持 store=int", e.TypeName, e.BaseType))
+		}
+		return nil
+	default:
+		return errors.New(errors.ErrCodeParse,
+			fmt.Sprintf("枚举 %s 的 store 选项无效: %s, 可选值为 string/int", e.TypeName, e.Store))
+	}
+}
+
+// isIntegerType function    判断是否为整数类型.
+func isIntegerType(typ string) bool {
+	switch typ {
+	case "int", "int8", "int16", "int32", "int64", "uint", "uint8", "uint16", "uint32", "uint64":
+		return true
+	}
+	return false
+}
+
+// lookupBaseType function    查找枚举类型的底层类型，先在当前文件查找，再扫描同目录文件.
+func lookupBaseType(typeName string, astFile *ast.File, dir string) string {
+	if typ := findTypeIdent(typeName, astFile); len(typ) > 0 {
+		return typ
+	}
+	pkgs, err := parser.ParseDir(token.NewFileSet(), dir, func(info os.FileInfo) bool {
+		return !strings.HasSuffix(info.Name(), "_test.go")
+	}, 0)
+	if err == nil {
+		for _, pkg := range pkgs {
+			for _, f := range pkg.Files {
+				if typ := findTypeIdent(typeName, f); len(typ) > 0 {
+					return typ
+				}
+			}
+		}
+	}
+	return "int"
+}
+
+// findTypeIdent function    查找文件中类型定义的底层标识符.
+func findTypeIdent(typeName string, astFile *ast.File) string {
+	for _, decl := range astFile.Decls {
+		genDecl, ok := decl.(*ast.GenDecl)
+		if !ok || genDecl.Tok != token.TYPE {
+			continue
+		}
+		for _, spec := range genDecl.Specs {
+			ts := spec.(*ast.TypeSpec)
+			if ts.Name.Name != typeName {
+				continue
+			}
+			if ident, ok := ts.Type.(*ast.Ident); ok {
+				return ident.Name
+			}
+		}
+	}
+	return ""
+}
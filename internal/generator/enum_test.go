@@ -0,0 +1,163 @@
+package generator
+
+import (
+	"go/parser"
+	"go/token"
+	"strings"
+	"testing"
+)
+
+// TestParseEnums function    测试解析 @enum 注解的常量块.
+func TestParseEnums(t *testing.T) {
+	tests := []struct {
+		name     string
+		src      string
+		wantType string
+		want     []string // 枚举值，格式为 常量名=标签
+		wantErr  string
+	}{
+		{
+			name: "iota 枚举",
+			src: `package model
+
+type Status int
+
+// @enum
+const (
+	StatusActive Status = iota
+	StatusDisabled
+)
+`,
+			wantType: "Status",
+			want:     []string{"StatusActive=active", "StatusDisabled=disabled"},
+		},
+		{
+			name: "类型切换结束枚举",
+			src: `package model
+
+type Status int
+
+type Kind int
+
+// @enum
+const (
+	StatusActive Status = iota
+	StatusDisabled
+	KindA Kind = iota
+	KindB
+)
+`,
+			wantType: "Status",
+			want:     []string{"StatusActive=active", "StatusDisabled=disabled"},
+		},
+		{
+			name: "排除无类型的显式赋值",
+			src: `package model
+
+type Status int
+
+// @enum
+const (
+	StatusActive Status = iota
+	StatusDisabled
+	maxStatus = 10
+	StatusUnknown
+)
+`,
+			wantType: "Status",
+			want:     []string{"StatusActive=active", "StatusDisabled=disabled"},
+		},
+		{
+			name: "字符串值作为标签",
+			src: `package model
+
+type Color string
+
+// @enum
+const (
+	ColorRed   Color = "red-color"
+	ColorGreen Color = "green-color"
+)
+`,
+			wantType: "Color",
+			want:     []string{"ColorRed=red-color", "ColorGreen=green-color"},
+		},
+		{
+			name: "@label 覆盖标签",
+			src: `package model
+
+type Status int
+
+// @enum
+const (
+	// 启用 @label("enabled")
+	StatusActive Status = iota
+	StatusDisabled // @label(off)
+)
+`,
+			wantType: "Status",
+			want:     []string{"StatusActive=enabled", "StatusDisabled=off"},
+		},
+		{
+			name: "跳过空白标识符",
+			src: `package model
+
+type Status int
+
+// @enum
+const (
+	_ Status = iota
+	StatusActive
+)
+`,
+			wantType: "Status",
+			want:     []string{"StatusActive=active"},
+		},
+		{
+			name: "未声明类型",
+			src: `package model
+
+// @enum
+const (
+	A = iota
+	B
+)
+`,
+			wantErr: "@enum 常量块必须声明类型, 位于 model.go:4",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fset := token.NewFileSet()
+			astFile, err := parser.ParseFile(fset, "model.go", tt.src, parser.ParseComments)
+			if err != nil {
+				t.Fatalf("ParseFile() error = %v", err)
+			}
+
+			enums, err := parseEnums(fset, astFile, t.TempDir())
+			if len(tt.wantErr) > 0 {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Errorf("parseEnums() error = %v, want contains %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("parseEnums() error = %v", err)
+			}
+			if len(enums) != 1 {
+				t.Fatalf("parseEnums() got %d enums, want 1", len(enums))
+			}
+			if enums[0].TypeName != tt.wantType {
+				t.Errorf("parseEnums() TypeName = %s, want %s", enums[0].TypeName, tt.wantType)
+			}
+			var got []string
+			for _, v := range enums[0].Values {
+				got = append(got, v.Name+"="+v.Label)
+			}
+			if strings.Join(got, ",") != strings.Join(tt.want, ",") {
+				t.Errorf("parseEnums() values = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,141 @@
+package generator
+
+import (
+	"strings"
+
+	"github.com/stoewer/go-strcase"
+)
+
+// newField function    根据字段名、类型、注释及结构体标签创建模型字段.
+func newField(name, typ, comment, tag string) Field {
+	f := Field{
+		Name:    name,
+		Type:    typ,
+		Comment: comment,
+		Tags:    parseStructTag(tag),
+	}
+	f.Gorm = parseGormTag(f.Tags["gorm"])
+
+	f.Column = gormValue(f.Gorm, "column")
+	if len(f.Column) == 0 {
+		f.Column = strcase.SnakeCase(name)
+	}
+	f.SQLType = gormValue(f.Gorm, "type")
+	f.Default = gormValue(f.Gorm, "default")
+	if len(f.Comment) == 0 {
+		f.Comment = strings.Trim(gormValue(f.Gorm, "comment"), "'")
+	}
+	f.PrimaryKey = gormHas(f.Gorm, "primaryKey")
+	f.AutoIncrement = gormHas(f.Gorm, "autoIncrement")
+	f.Indexes = splitList(gormValue(f.Gorm, "index"))
+	f.UniqueIndexes = splitList(gormValue(f.Gorm, "uniqueIndex"))
+	f.Nullable = isNullableType(typ) || (len(f.SQLType) > 0 && !f.PrimaryKey && !gormHas(f.Gorm, "not null"))
+
+	f.JSON = name
+	if json, ok := f.Tags["json"]; ok {
+		if n, _, _ := strings.Cut(json, ","); len(n) > 0 {
+			f.JSON = n
+		}
+	}
+	return f
+}
+
+// primaryKey function    返回主键字段，gorm 标签未声明主键时使用名为 ID 的字段，均不存在时返回 int 类型的 ID.
+func primaryKey(fields []Field) Field {
+	for _, f := range fields {
+		if f.PrimaryKey {
+			return f
+		}
+	}
+	for _, f := range fields {
+		if strings.EqualFold(f.Name, "id") {
+			return f
+		}
+	}
+	return Field{Name: "ID", Type: "int", Column: "id", JSON: "id", PrimaryKey: true}
+}
+
+// parseStructTag function    解析结构体标签为 标签名 → 值 的映射，格式同 reflect.StructTag.
+func parseStructTag(tag string) map[string]string {
+	tags := make(map[string]string)
+	for tag = strings.TrimSpace(tag); len(tag) > 0; tag = strings.TrimSpace(tag) {
+		i := strings.Index(tag, `:"`)
+		if i <= 0 || strings.ContainsAny(tag[:i], " \t\"") {
+			break
+		}
+		key, rest := tag[:i], tag[i+1:]
+		// 查找值的结束引号，跳过转义字符
+		j := 1
+		for j < len(rest) && rest[j] != '"' {
+			if rest[j] == '\\' {
+				j++
+			}
+			j++
+		}
+		if j >= len(rest) {
+			break
+		}
+		value := rest[1:j]
+		value = strings.ReplaceAll(value, `\"`, `"`)
+		value = strings.ReplaceAll(value, `\\`, `\`)
+		tags[key] = value
+		tag = rest[j+1:]
+	}
+	return tags
+}
+
+// parseGormTag function    解析 gorm 标签为 标签项名 → 值 的映射，如 column:id;primaryKey.
+func parseGormTag(tag string) map[string]string {
+	settings := make(map[string]string)
+	for _, item := range strings.Split(tag, ";") {
+		item = strings.TrimSpace(item)
+		if len(item) == 0 {
+			continue
+		}
+		key, value, _ := strings.Cut(item, ":")
+		settings[strings.TrimSpace(key)] = strings.TrimSpace(value)
+	}
+	return settings
+}
+
+// normalizeGormKey function    统一 gorm 标签项名，兼容 primary_key、PRIMARYKEY 等写法.
+func normalizeGormKey(key string) string {
+	return strings.ToLower(strings.NewReplacer("_", "", " ", "").Replace(key))
+}
+
+// gormValue function    返回 gorm 标签项的值，兼容旧版下划线写法，如 uniqueIndex 同时匹配 unique_index.
+func gormValue(settings map[string]string, key string) string {
+	key = normalizeGormKey(key)
+	for k, v := range settings {
+		if normalizeGormKey(k) == key {
+			return v
+		}
+	}
+	return ""
+}
+
+// gormHas function    判断是否声明了 gorm 标签项.
+func gormHas(settings map[string]string, key string) bool {
+	key = normalizeGormKey(key)
+	for k := range settings {
+		if normalizeGormKey(k) == key {
+			return true
+		}
+	}
+	return false
+}
+
+// splitList function    按逗号拆分列表并去除空项.
+func splitList(s string) (list []string) {
+	for _, item := range strings.Split(s, ",") {
+		if item = strings.TrimSpace(item); len(item) > 0 {
+			list = append(list, item)
+		}
+	}
+	return list
+}
+
+// isNullableType function    判断字段类型是否可以表示空值.
+func isNullableType(typ string) bool {
+	return strings.HasPrefix(typ, "*") || strings.HasPrefix(typ, "sql.Null") || typ == "gorm.DeletedAt"
+}
@@ -22,14 +22,16 @@ import (
 	"golang.org/x/sync/errgroup"
 )
 
-var defaultImplTemplate = template.Must(template.New("impl").Parse(template2.DefaultImplTemplate))
+var defaultImplTemplate = template.Must(utils.NewTemplate("impl").Parse(template2.DefaultImplTemplate))
 
-// Impl struct 定义接口实现结构体.
+// Impl struct 定义接口实现结构体，作为实现文件路径模板的渲染数据.
 type Impl struct {
-	InterfaceName  string
-	ImplPackage    string
-	SetName        string
-	ImplStructName string
+	InterfaceName        string
+	InterfacePackageName string
+	SnakeIfaceName       string
+	ImplPackage          string
+	SetName              string
+	ImplStructName       string
 }
 
 // implsSync struct    实现同步器：负责将接口定义同步到具体的实现代码中.
@@ -42,6 +44,7 @@ type implsSync struct {
 	SetName              string
 
 	implDir            string
+	implStructFile     string
 	ifaceAstType       *ast.InterfaceType
 	implStructTemplate *template.Template
 	interfaceFileSet   *token.FileSet
@@ -54,6 +57,8 @@ type Config struct {
 	Scope            string
 	ImplementsDir    string
 	Prefix           string
+	Path             string // 实现结构体文件路径模板，为空时生成在 ImplementsDir 下
+	StructName       string // 实现结构体名，为空时使用 SetName
 }
 
 // SyncInterfaceImpls method    同步接口实现.
@@ -78,6 +83,17 @@ func (cfg Config) SyncInterfaceImpls() (err error) {
 		cfg.ImplementsDir = filepath.Join("./", "internal", fmt.Sprintf("%s_impls", strcase.SnakeCase(cfg.Prefix)))
 	}
 
+	if len(cfg.StructName) == 0 {
+		cfg.StructName = strcase.UpperCamelCase(cfg.SetName)
+	}
+
+	var pathTemplate *template.Template
+	if len(cfg.Path) > 0 {
+		if pathTemplate, err = utils.NewTemplate("path").Parse(cfg.Path); err != nil {
+			return errors.WrapWithCode(err, errors.ErrCodeTemplate, fmt.Sprintf("解析实现文件路径失败: %s", err))
+		}
+	}
+
 	interfaces := matchInterface(cfg.Scope, cfg.SetName)
 	wg := errgroup.Group{}
 	for _, item := range interfaces {
@@ -85,15 +101,32 @@ func (cfg Config) SyncInterfaceImpls() (err error) {
 		wg.Go(func() (err error) {
 			implPackage := fmt.Sprintf("%s_%s", strcase.SnakeCase(cfg.Prefix), strcase.SnakeCase(item.Name))
 			targetDir := filepath.Join(cfg.ImplementsDir, implPackage)
+			structFile := filepath.Join(targetDir, "init.go")
+			if pathTemplate != nil {
+				fp, err := utils.ExecuteTemplate(pathTemplate, Impl{
+					InterfaceName:        item.Name,
+					InterfacePackageName: item.PackageName,
+					SnakeIfaceName:       strcase.SnakeCase(item.Name),
+					ImplPackage:          implPackage,
+					SetName:              cfg.SetName,
+					ImplStructName:       cfg.StructName,
+				})
+				if err != nil {
+					return errors.WrapWithCode(err, errors.ErrCodeTemplate, fmt.Sprintf("渲染实现文件路径失败: %s", err))
+				}
+				structFile = string(fp)
+				targetDir = filepath.Dir(structFile)
+			}
 			syncer := implsSync{
 				InterfacePackagePath: item.PackagePath,
 				InterfacePackageName: item.PackageName,
 				InterfaceName:        item.Name,
 				ImplPackage:          implPackage,
-				ImplStructName:       strcase.UpperCamelCase(cfg.SetName),
+				ImplStructName:       cfg.StructName,
 				SetName:              cfg.SetName,
 				ifaceAstType:         item.IfaceType,
 				implDir:              targetDir,
+				implStructFile:       structFile,
 				implStructTemplate:   cfg.ImplBaseTemplate,
 				interfaceFileSet:     item.fileSet,
 			}
@@ -196,7 +229,7 @@ func (s implsSync) sync() (updated int, err error) {
 
 	// 未有实现结构体 创建
 	if len(implStructDeclPath) == 0 {
-		fp := filepath.Join(s.implDir, "init.go")
+		fp := s.implStructFile
 		logger.Info("implement for [ %s.%s ] not found,create in [ %s ]", s.InterfacePackageName, s.InterfaceName, fp)
 		if err = utils.ExecuteTemplateAndWrite(s.implStructTemplate, s, fp); err != nil {
 			return 0, errors.WrapWithCode(err, errors.ErrCodeGenerate, fmt.Sprintf("生成实现结构体文件失败:%s", err))
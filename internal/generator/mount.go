@@ -12,6 +12,7 @@ import (
 
 	"github.com/spelens-gud/gsus/internal/config"
 	"github.com/spelens-gud/gsus/internal/errors"
+	"github.com/spelens-gud/gsus/internal/logger"
 	"github.com/spelens-gud/gsus/internal/parser"
 	"github.com/spelens-gud/gsus/internal/utils"
 	"github.com/stoewer/go-strcase"
@@ -45,6 +46,22 @@ func Exec(cfg config.Mount) (err error) {
 		cfg.Name = "mount"
 	}
 
+	// 配置了挂载目标时，直接将 @name 注解的类型及函数参数挂载到目标结构体
+	if len(cfg.Path) > 0 && len(cfg.Struct) > 0 {
+		match := matchFields(cfg.Scope, cfg.Name, true)
+		if len(match) == 0 {
+			logger.Warn("未找到 @%s 注解的挂载类型", cfg.Name)
+			return nil
+		}
+		if err = ExecFields(Option{
+			Path:   cfg.Path,
+			Struct: cfg.Struct,
+		}, match); err != nil {
+			return errors.WrapWithCode(err, errors.ErrCodeExecute, fmt.Sprintf("执行挂载失败: %s", err))
+		}
+		return nil
+	}
+
 	// 查找所有匹配的挂载目标结构体
 	mountTargetStructs := matchFields(cfg.Scope, cfg.Name, false)
 	if len(mountTargetStructs) == 0 {
@@ -93,7 +110,7 @@ func Exec(cfg config.Mount) (err error) {
 // funcParams: 是否匹配函数参数.
 func matchFields(scope string, ident string, funcParams bool) (fields []MatchStruct) {
 	// 编译正则表达式，用于匹配 @ident(...) 格式的注解
-	regexConfig, err := regexp.Compile(`@` + ident + `\\((.*?)\\)`)
+	regexConfig, err := regexp.Compile(`@` + regexp.QuoteMeta(ident) + `\((.*?)\)`)
 	if err != nil {
 		return
 	}
@@ -0,0 +1,142 @@
+package generator
+
+import (
+	"strings"
+)
+
+// openapiDoc struct    OpenAPI 3.1 文档.
+type openapiDoc struct {
+	OpenAPI    string                                  `json:"openapi"`
+	Info       swaggerInfo                             `json:"info"`
+	Servers    []openapiServer                         `json:"servers,omitempty"`
+	Tags       []swaggerTag                            `json:"tags,omitempty"`
+	Paths      map[string]map[string]*openapiOperation `json:"paths"`
+	Components *openapiComponents                      `json:"components,omitempty"`
+}
+
+// openapiServer struct    服务地址.
+type openapiServer struct {
+	URL string `json:"url"`
+}
+
+// openapiComponents struct    公共组件.
+type openapiComponents struct {
+	Schemas map[string]*jsonSchema `json:"schemas,omitempty"`
+}
+
+// openapiOperation struct    OpenAPI 3.1 单个接口定义.
+type openapiOperation struct {
+	Tags        []string                    `json:"tags,omitempty"`
+	Summary     string                      `json:"summary,omitempty"`
+	Description string                      `json:"description,omitempty"`
+	OperationID string                      `json:"operationId,omitempty"`
+	Parameters  []*openapiParameter         `json:"parameters,omitempty"`
+	RequestBody *openapiRequestBody         `json:"requestBody,omitempty"`
+	Responses   map[string]*openapiResponse `json:"responses"`
+}
+
+// openapiParameter struct    OpenAPI 3.1 接口参数.
+type openapiParameter struct {
+	Name        string      `json:"name"`
+	In          string      `json:"in"`
+	Description string      `json:"description,omitempty"`
+	Required    bool        `json:"required,omitempty"`
+	Explode     *bool       `json:"explode,omitempty"`
+	Schema      *jsonSchema `json:"schema"`
+}
+
+// openapiRequestBody struct    OpenAPI 3.1 请求体.
+type openapiRequestBody struct {
+	Required bool                         `json:"required,omitempty"`
+	Content  map[string]*openapiMediaType `json:"content"`
+}
+
+// openapiResponse struct    OpenAPI 3.1 接口响应.
+type openapiResponse struct {
+	Description string                       `json:"description"`
+	Content     map[string]*openapiMediaType `json:"content,omitempty"`
+}
+
+// openapiMediaType struct    指定内容类型的数据结构.
+type openapiMediaType struct {
+	Schema *jsonSchema `json:"schema,omitempty"`
+}
+
+// newOpenAPIDoc function    将接口定义组装为 OpenAPI 3.1 文档.
+func newOpenAPIDoc(general swaggerGeneral, produce string, tags []swaggerTag, ops []*apiOperation,
+	definitions map[string]*jsonSchema) *openapiDoc {
+	doc := &openapiDoc{
+		OpenAPI: "3.1.0",
+		Info:    general.Info,
+		Servers: openapiServers(general),
+		Tags:    tags,
+		Paths:   make(map[string]map[string]*openapiOperation),
+	}
+	if len(definitions) > 0 {
+		doc.Components = &openapiComponents{Schemas: definitions}
+	}
+
+	for _, op := range ops {
+		oop := &openapiOperation{
+			Tags:        []string{op.Tag},
+			Summary:     op.Summary,
+			Description: op.Description,
+			OperationID: op.OperationID,
+			Responses:   make(map[string]*openapiResponse),
+		}
+		for _, param := range op.Params {
+			p := &openapiParameter{
+				Name:        param.Name,
+				In:          param.In,
+				Description: param.Description,
+				Required:    param.Required,
+				Schema:      param.Schema,
+			}
+			if param.Schema.Type == "array" {
+				// 数组参数以 ?id=1&id=2 形式传递
+				explode := true
+				p.Explode = &explode
+			}
+			oop.Parameters = append(oop.Parameters, p)
+		}
+		if op.Body != nil {
+			oop.RequestBody = &openapiRequestBody{
+				Required: true,
+				Content:  map[string]*openapiMediaType{swaggerDefaultConsume: {Schema: op.Body}},
+			}
+		}
+		for _, resp := range op.Responses {
+			r := &openapiResponse{Description: resp.Description}
+			if resp.Schema != nil {
+				r.Content = map[string]*openapiMediaType{produce: {Schema: resp.Schema}}
+			}
+			oop.Responses[resp.Code] = r
+		}
+
+		if doc.Paths[op.Path] == nil {
+			doc.Paths[op.Path] = make(map[string]*openapiOperation)
+		}
+		doc.Paths[op.Path][op.Method] = oop
+	}
+	return doc
+}
+
+// openapiServers function    根据 host/basePath/schemes 生成服务地址列表.
+func openapiServers(general swaggerGeneral) (servers []openapiServer) {
+	basePath := strings.TrimRight(general.BasePath, "/")
+	if len(general.Host) == 0 {
+		if len(basePath) > 0 {
+			servers = append(servers, openapiServer{URL: basePath})
+		}
+		return
+	}
+
+	schemes := general.Schemes
+	if len(schemes) == 0 {
+		schemes = []string{"http"}
+	}
+	for _, scheme := range schemes {
+		servers = append(servers, openapiServer{URL: scheme + "://" + general.Host + basePath})
+	}
+	return
+}
@@ -0,0 +1,1064 @@
+package generator
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"text/template"
+
+	"github.com/spelens-gud/gsus/internal/errors"
+	"github.com/spelens-gud/gsus/internal/logger"
+	template2 "github.com/spelens-gud/gsus/internal/template"
+	"github.com/spelens-gud/gsus/internal/utils"
+	"github.com/stoewer/go-strcase"
+	"gopkg.in/yaml.v3"
+)
+
+// importTypesFile 组件数据结构输出文件名.
+const importTypesFile = "openapi_types.go"
+
+var (
+	httpImportTemplate = template.Must(utils.NewTemplate("http_import").Parse(template2.DefaultHttpImportTemplate))
+	importIdentRegex   = regexp.MustCompile(`[^A-Za-z0-9_]+`)
+	importMethods      = []string{"get", "post", "put", "patch", "delete", "head", "options"}
+)
+
+// ImportOpenAPIConfig struct    OpenAPI 文档导入配置.
+type ImportOpenAPIConfig struct {
+	Spec      string // OpenAPI 3.x/Swagger 2.0 文档路径，支持 yaml/json
+	Dir       string // 输出目录
+	Overwrite bool   // 是否覆盖已存在的文件
+}
+
+// importFile struct    导入生成的单个 Go 文件.
+type importFile struct {
+	Package  string           // 包名
+	Source   string           // 文档文件名
+	Services []*importService // 服务定义
+	Decls    []string         // 数据结构定义
+}
+
+// importService struct    导入生成的 @service 接口.
+type importService struct {
+	Name          string          // 服务名
+	InterfaceName string          // 接口名
+	Title         string          // 接口注释
+	Route         string          // 组路由前缀
+	Methods       []*importMethod // 接口方法
+	decls         []string
+	methodNames   map[string]bool
+}
+
+// importMethod struct    导入生成的 @http 方法.
+type importMethod struct {
+	Name       string   // 方法名
+	Title      string   // 标题
+	Doc        []string // 文档注释
+	HttpMethod string   // 小写 HTTP 方法
+	Route      string   // 相对组路由的路由
+	Param      string   // 请求参数类型
+	Return     string   // 返回值类型
+	path       string
+}
+
+// ImportOpenAPI function    根据 OpenAPI 文档生成 @service 接口及请求/响应结构体.
+func ImportOpenAPI(cfg ImportOpenAPIConfig) (err error) {
+	doc, err := loadOASDoc(cfg.Spec)
+	if err != nil {
+		return err
+	}
+	imp := newOASImporter(doc)
+
+	pkg := dirPackageName(cfg.Dir)
+	source := filepath.Base(cfg.Spec)
+
+	// 组件数据结构
+	types := importFile{Package: pkg, Source: source}
+	for _, ref := range imp.componentRefs {
+		types.Decls = imp.componentDecl(ref, types.Decls)
+	}
+
+	// 接口定义
+	services, err := imp.services()
+	if err != nil {
+		return err
+	}
+
+	for _, svc := range services {
+		file := importFile{Package: pkg, Source: source, Services: []*importService{svc}, Decls: svc.decls}
+		if err = writeImportFile(file, filepath.Join(cfg.Dir, svc.Name+".go"), cfg.Overwrite); err != nil {
+			return err
+		}
+	}
+	if len(types.Decls) > 0 {
+		if err = writeImportFile(types, filepath.Join(cfg.Dir, importTypesFile), cfg.Overwrite); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// writeImportFile function    渲染并写入导入生成的文件，文件已存在且不覆盖时先将原文件写入 .bak 文件.
+func writeImportFile(file importFile, path string, overwrite bool) error {
+	data, err := utils.ExecuteTemplate(httpImportTemplate, file)
+	if err != nil {
+		return err
+	}
+	if err = backupFile(path, overwrite); err != nil {
+		return err
+	}
+	logger.Info("generating openapi import [ %s ]", path)
+	if err = utils.ImportAndWrite(data, path); err != nil {
+		return errors.WrapWithCode(err, errors.ErrCodeGenerate, fmt.Sprintf("写入 %s 失败: %s", path, err))
+	}
+	return nil
+}
+
+// oasMap struct    保持声明顺序的映射.
+type oasMap[T any] struct {
+	Keys   []string     // 键，按声明顺序排列
+	Values map[string]T // 值
+}
+
+// UnmarshalYAML method    按声明顺序解析映射.
+func (m *oasMap[T]) UnmarshalYAML(node *yaml.Node) error {
+	if node.Kind != yaml.MappingNode {
+		return fmt.Errorf("line %d: expected mapping", node.Line)
+	}
+	m.Values = make(map[string]T, len(node.Content)/2)
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		key := node.Content[i].Value
+		var value T
+		if err := node.Content[i+1].Decode(&value); err != nil {
+			return err
+		}
+		if _, dup := m.Values[key]; !dup {
+			m.Keys = append(m.Keys, key)
+		}
+		m.Values[key] = value
+	}
+	return nil
+}
+
+// oasType []string    数据类型，兼容 OpenAPI 3.1 的类型数组.
+type oasType []string
+
+// UnmarshalYAML method    解析单个类型或类型数组.
+func (t *oasType) UnmarshalYAML(node *yaml.Node) error {
+	if node.Kind == yaml.SequenceNode {
+		var types []string
+		if err := node.Decode(&types); err != nil {
+			return err
+		}
+		*t = types
+		return nil
+	}
+	*t = oasType{node.Value}
+	return nil
+}
+
+// oasAdditional struct    additionalProperties，可为布尔值或数据结构.
+type oasAdditional struct {
+	Allowed bool       // 是否允许额外属性
+	Schema  *oasSchema // 额外属性的数据结构
+}
+
+// UnmarshalYAML method    解析布尔值或数据结构.
+func (a *oasAdditional) UnmarshalYAML(node *yaml.Node) error {
+	if node.Kind == yaml.ScalarNode {
+		return node.Decode(&a.Allowed)
+	}
+	a.Allowed = true
+	return node.Decode(&a.Schema)
+}
+
+// oasSchema struct    文档中的数据结构定义.
+type oasSchema struct {
+	Ref                  string             `yaml:"$ref"`
+	Type                 oasType            `yaml:"type"`
+	Format               string             `yaml:"format"`
+	Title                string             `yaml:"title"`
+	Description          string             `yaml:"description"`
+	Items                *oasSchema         `yaml:"items"`
+	Properties           oasMap[*oasSchema] `yaml:"properties"`
+	AdditionalProperties *oasAdditional     `yaml:"additionalProperties"`
+	Required             []string           `yaml:"required"`
+	AllOf                []*oasSchema       `yaml:"allOf"`
+	AnyOf                []*oasSchema       `yaml:"anyOf"`
+	OneOf                []*oasSchema       `yaml:"oneOf"`
+	Enum                 []interface{}      `yaml:"enum"`
+	Nullable             bool               `yaml:"nullable"`
+	XNullable            bool               `yaml:"x-nullable"`
+}
+
+// primaryType method    返回除 null 以外的数据类型及是否可为空.
+func (s *oasSchema) primaryType() (typ string, nullable bool) {
+	nullable = s.Nullable || s.XNullable
+	for _, t := range s.Type {
+		if t == "null" {
+			nullable = true
+			continue
+		}
+		if len(typ) == 0 {
+			typ = t
+		}
+	}
+	if len(typ) == 0 && (len(s.Properties.Keys) > 0 || s.AdditionalProperties != nil) {
+		typ = "object"
+	}
+	for _, alt := range s.unions() {
+		if t, _ := alt.primaryType(); len(alt.Ref) == 0 && t == "" && len(alt.Type) > 0 {
+			nullable = true
+		}
+	}
+	return
+}
+
+// unions method    返回 oneOf 及 anyOf 中的数据结构.
+func (s *oasSchema) unions() []*oasSchema {
+	return append(append([]*oasSchema{}, s.OneOf...), s.AnyOf...)
+}
+
+// alternatives method    返回 oneOf/anyOf 中除 null 以外的数据结构.
+func (s *oasSchema) alternatives() (alts []*oasSchema) {
+	for _, alt := range s.unions() {
+		if t, _ := alt.primaryType(); len(alt.Ref) > 0 || len(t) > 0 || len(alt.Type) == 0 {
+			alts = append(alts, alt)
+		}
+	}
+	return
+}
+
+// stringEnum method    返回字符串枚举值，非字符串枚举返回 nil.
+func (s *oasSchema) stringEnum() (labels []string) {
+	if typ, _ := s.primaryType(); typ != "string" {
+		return nil
+	}
+	for _, v := range s.Enum {
+		label, ok := v.(string)
+		if !ok {
+			return nil
+		}
+		labels = append(labels, label)
+	}
+	return
+}
+
+// oasParameter struct    接口参数.
+type oasParameter struct {
+	Ref         string        `yaml:"$ref"`
+	Name        string        `yaml:"name"`
+	In          string        `yaml:"in"`
+	Description string        `yaml:"description"`
+	Required    bool          `yaml:"required"`
+	Schema      *oasSchema    `yaml:"schema"`
+	Type        oasType       `yaml:"type"`
+	Format      string        `yaml:"format"`
+	Items       *oasSchema    `yaml:"items"`
+	Enum        []interface{} `yaml:"enum"`
+}
+
+// schema method    返回参数的数据结构，Swagger 2.0 非 body 参数直接声明类型.
+func (p *oasParameter) schema() *oasSchema {
+	if p.Schema != nil {
+		return p.Schema
+	}
+	return &oasSchema{Type: p.Type, Format: p.Format, Items: p.Items, Enum: p.Enum}
+}
+
+// oasMediaType struct    指定内容类型的数据结构.
+type oasMediaType struct {
+	Schema *oasSchema `yaml:"schema"`
+}
+
+// oasRequestBody struct    OpenAPI 3.x 请求体.
+type oasRequestBody struct {
+	Ref         string                `yaml:"$ref"`
+	Description string                `yaml:"description"`
+	Content     oasMap[*oasMediaType] `yaml:"content"`
+}
+
+// oasResponse struct    接口响应.
+type oasResponse struct {
+	Ref         string                `yaml:"$ref"`
+	Description string                `yaml:"description"`
+	Schema      *oasSchema            `yaml:"schema"`
+	Content     oasMap[*oasMediaType] `yaml:"content"`
+}
+
+// oasOperation struct    单个接口定义.
+type oasOperation struct {
+	OperationID string               `yaml:"operationId"`
+	Summary     string               `yaml:"summary"`
+	Description string               `yaml:"description"`
+	Tags        []string             `yaml:"tags"`
+	Deprecated  bool                 `yaml:"deprecated"`
+	Parameters  []*oasParameter      `yaml:"parameters"`
+	RequestBody *oasRequestBody      `yaml:"requestBody"`
+	Responses   oasMap[*oasResponse] `yaml:"responses"`
+}
+
+// oasPathItem struct    单个路径下的接口定义.
+type oasPathItem struct {
+	Parameters []*oasParameter `yaml:"parameters"`
+	Get        *oasOperation   `yaml:"get"`
+	Put        *oasOperation   `yaml:"put"`
+	Post       *oasOperation   `yaml:"post"`
+	Delete     *oasOperation   `yaml:"delete"`
+	Options    *oasOperation   `yaml:"options"`
+	Head       *oasOperation   `yaml:"head"`
+	Patch      *oasOperation   `yaml:"patch"`
+}
+
+// operation method    返回指定方法的接口定义.
+func (p *oasPathItem) operation(method string) *oasOperation {
+	switch method {
+	case "get":
+		return p.Get
+	case "put":
+		return p.Put
+	case "post":
+		return p.Post
+	case "delete":
+		return p.Delete
+	case "options":
+		return p.Options
+	case "head":
+		return p.Head
+	case "patch":
+		return p.Patch
+	}
+	return nil
+}
+
+// oasTag struct    接口分组.
+type oasTag struct {
+	Name        string `yaml:"name"`
+	Description string `yaml:"description"`
+}
+
+// oasComponents struct    OpenAPI 3.x 公共组件.
+type oasComponents struct {
+	Schemas       oasMap[*oasSchema]         `yaml:"schemas"`
+	Parameters    map[string]*oasParameter   `yaml:"parameters"`
+	RequestBodies map[string]*oasRequestBody `yaml:"requestBodies"`
+	Responses     map[string]*oasResponse    `yaml:"responses"`
+}
+
+// oasDoc struct    OpenAPI 3.x/Swagger 2.0 文档.
+type oasDoc struct {
+	Swagger     string                   `yaml:"swagger"`
+	OpenAPI     string                   `yaml:"openapi"`
+	Tags        []oasTag                 `yaml:"tags"`
+	Paths       oasMap[*oasPathItem]     `yaml:"paths"`
+	Definitions oasMap[*oasSchema]       `yaml:"definitions"`
+	Parameters  map[string]*oasParameter `yaml:"parameters"`
+	Responses   map[string]*oasResponse  `yaml:"responses"`
+	Components  oasComponents            `yaml:"components"`
+}
+
+// loadOASDoc function    读取并解析 OpenAPI 文档.
+func loadOASDoc(spec string) (*oasDoc, error) {
+	data, err := os.ReadFile(spec)
+	if err != nil {
+		return nil, errors.WrapWithCode(err, errors.ErrCodeFile, fmt.Sprintf("读取 OpenAPI 文档失败: %s", err))
+	}
+	// JSON 文档先压缩，避免缩进中的制表符不被 yaml 接受
+	if trimmed := bytes.TrimSpace(data); len(trimmed) > 0 && trimmed[0] == '{' {
+		var bf bytes.Buffer
+		if err = json.Compact(&bf, trimmed); err != nil {
+			return nil, errors.WrapWithCode(err, errors.ErrCodeParse, fmt.Sprintf("解析 OpenAPI 文档失败: %s", err))
+		}
+		data = bf.Bytes()
+	}
+
+	var doc oasDoc
+	if err = yaml.Unmarshal(data, &doc); err != nil {
+		return nil, errors.WrapWithCode(err, errors.ErrCodeParse, fmt.Sprintf("解析 OpenAPI 文档失败: %s", err))
+	}
+	if len(doc.Swagger) == 0 && len(doc.OpenAPI) == 0 {
+		return nil, errors.New(errors.ErrCodeParse, fmt.Sprintf("%s 不是 OpenAPI/Swagger 文档", spec))
+	}
+	return &doc, nil
+}
+
+// refName function    返回引用的名称，如 #/components/schemas/User 返回 User.
+func refName(ref string) string {
+	name := ref[strings.LastIndex(ref, "/")+1:]
+	return strings.NewReplacer("~1", "/", "~0", "~").Replace(name)
+}
+
+// schema method    解析数据结构引用.
+func (d *oasDoc) schema(ref string) *oasSchema {
+	if strings.HasPrefix(ref, "#/components/") {
+		return d.Components.Schemas.Values[refName(ref)]
+	}
+	return d.Definitions.Values[refName(ref)]
+}
+
+// parameter method    解析参数引用.
+func (d *oasDoc) parameter(p *oasParameter) *oasParameter {
+	for i := 0; p != nil && len(p.Ref) > 0 && i < maxEmbedDepth; i++ {
+		if strings.HasPrefix(p.Ref, "#/components/") {
+			p = d.Components.Parameters[refName(p.Ref)]
+		} else {
+			p = d.Parameters[refName(p.Ref)]
+		}
+	}
+	return p
+}
+
+// requestBody method    解析请求体引用.
+func (d *oasDoc) requestBody(rb *oasRequestBody) *oasRequestBody {
+	for i := 0; rb != nil && len(rb.Ref) > 0 && i < maxEmbedDepth; i++ {
+		rb = d.Components.RequestBodies[refName(rb.Ref)]
+	}
+	return rb
+}
+
+// response method    解析响应引用.
+func (d *oasDoc) response(resp *oasResponse) *oasResponse {
+	for i := 0; resp != nil && len(resp.Ref) > 0 && i < maxEmbedDepth; i++ {
+		if strings.HasPrefix(resp.Ref, "#/components/") {
+			resp = d.Components.Responses[refName(resp.Ref)]
+		} else {
+			resp = d.Responses[refName(resp.Ref)]
+		}
+	}
+	return resp
+}
+
+// mediaSchema function    按内容类型优先级返回数据结构，优先使用 JSON.
+func mediaSchema(content oasMap[*oasMediaType]) (contentType string, schema *oasSchema) {
+	for _, key := range content.Keys {
+		if mt := content.Values[key]; mt != nil && strings.Contains(key, "json") {
+			return key, mt.Schema
+		}
+	}
+	for _, key := range content.Keys {
+		if mt := content.Values[key]; mt != nil {
+			return key, mt.Schema
+		}
+	}
+	return "", nil
+}
+
+// oasImporter struct    将 OpenAPI 数据结构转换为 Go 代码.
+type oasImporter struct {
+	doc           *oasDoc
+	componentRefs []string          // 组件引用，按声明顺序排列
+	typeNames     map[string]string // 组件引用 -> Go 类型名
+	structs       map[string]bool   // 结构体类型名
+	used          map[string]bool   // 已使用的类型名
+}
+
+// newOASImporter function    创建转换器，并为所有组件预留类型名.
+func newOASImporter(doc *oasDoc) *oasImporter {
+	imp := &oasImporter{
+		doc:       doc,
+		typeNames: make(map[string]string),
+		structs:   make(map[string]bool),
+		used:      make(map[string]bool),
+	}
+	for _, key := range doc.Definitions.Keys {
+		imp.componentRefs = append(imp.componentRefs, "#/definitions/"+key)
+	}
+	for _, key := range doc.Components.Schemas.Keys {
+		imp.componentRefs = append(imp.componentRefs, "#/components/schemas/"+key)
+	}
+	for _, ref := range imp.componentRefs {
+		imp.typeNames[ref] = imp.uniqueName(goIdentName(refName(ref)))
+	}
+	for _, ref := range imp.componentRefs {
+		if imp.isStruct(doc.schema(ref), 0) {
+			imp.structs[imp.typeNames[ref]] = true
+		}
+	}
+	return imp
+}
+
+// uniqueName method    返回包内唯一的类型名.
+func (imp *oasImporter) uniqueName(name string) string {
+	unique := name
+	for i := 2; imp.used[unique]; i++ {
+		unique = fmt.Sprintf("%s%d", name, i)
+	}
+	imp.used[unique] = true
+	return unique
+}
+
+// refType method    返回引用对应的 Go 类型名.
+func (imp *oasImporter) refType(ref string) string {
+	if name, ok := imp.typeNames[ref]; ok {
+		return name
+	}
+	return "interface{}"
+}
+
+// isStruct method    判断数据结构是否生成为结构体.
+func (imp *oasImporter) isStruct(s *oasSchema, depth int) bool {
+	if s == nil || depth > maxEmbedDepth {
+		return false
+	}
+	if len(s.Ref) > 0 {
+		return imp.isStruct(imp.doc.schema(s.Ref), depth+1)
+	}
+	if len(s.OneOf)+len(s.AnyOf) > 0 {
+		alts := s.alternatives()
+		return len(alts) == 1 && imp.isStruct(alts[0], depth+1)
+	}
+	if len(s.AllOf) == 1 && len(s.Properties.Keys) == 0 {
+		return imp.isStruct(s.AllOf[0], depth+1)
+	}
+	if len(s.AllOf) > 0 {
+		return true
+	}
+	typ, _ := s.primaryType()
+	return typ == "object" && len(s.Properties.Keys) > 0
+}
+
+// isInlineStruct method    判断数据结构是否直接声明了结构体字段，而非引用其他类型.
+func (imp *oasImporter) isInlineStruct(s *oasSchema) bool {
+	return s != nil && len(s.Ref) == 0 && len(s.OneOf)+len(s.AnyOf) == 0 &&
+		!(len(s.AllOf) == 1 && len(s.Properties.Keys) == 0) && imp.isStruct(s, 0)
+}
+
+// nullable method    判断数据结构是否可为空.
+func (imp *oasImporter) nullable(s *oasSchema) bool {
+	_, nullable := s.primaryType()
+	return nullable
+}
+
+// goType method    返回数据结构对应的 Go 类型，内联对象以 name 为类型名追加到 decls.
+func (imp *oasImporter) goType(s *oasSchema, name string, decls *[]string) string {
+	if s == nil {
+		return "interface{}"
+	}
+	if len(s.Ref) > 0 {
+		return imp.refType(s.Ref)
+	}
+	if len(s.OneOf)+len(s.AnyOf) > 0 {
+		if alts := s.alternatives(); len(alts) == 1 {
+			return imp.goType(alts[0], name, decls)
+		}
+		return "interface{}"
+	}
+	if len(s.AllOf) == 1 && len(s.Properties.Keys) == 0 {
+		return imp.goType(s.AllOf[0], name, decls)
+	}
+
+	typ, _ := s.primaryType()
+	if len(s.AllOf) > 0 {
+		typ = "object"
+	}
+	switch typ {
+	case "string":
+		switch s.Format {
+		case "date-time":
+			return "time.Time"
+		case "byte", "binary":
+			return "[]byte"
+		}
+		return "string"
+	case "integer":
+		switch s.Format {
+		case "int32":
+			return "int32"
+		case "int64":
+			return "int64"
+		}
+		return "int"
+	case "number":
+		if s.Format == "float" {
+			return "float32"
+		}
+		return "float64"
+	case "boolean":
+		return "bool"
+	case "file":
+		return "*multipart.FileHeader"
+	case "array":
+		return "[]" + imp.goType(s.Items, name+"Item", decls)
+	case "object":
+		if len(s.Properties.Keys) > 0 || len(s.AllOf) > 0 {
+			name = imp.uniqueName(name)
+			imp.structs[name] = true
+			*decls = imp.structDecl(name, schemaDoc(s), s, *decls)
+			return name
+		}
+		if s.AdditionalProperties != nil && s.AdditionalProperties.Schema != nil {
+			return "map[string]" + imp.goType(s.AdditionalProperties.Schema, name+"Value", decls)
+		}
+		return "map[string]interface{}"
+	}
+	return "interface{}"
+}
+
+// fieldType method    返回结构体字段类型，可空的基础类型及非必填的结构体使用指针.
+func (imp *oasImporter) fieldType(s *oasSchema, name string, required bool, decls *[]string) string {
+	typ := imp.goType(s, name, decls)
+	nullable := s != nil && imp.nullable(s)
+	if imp.structs[typ] && (!required || nullable) {
+		return "*" + typ
+	}
+	if nullable && !strings.HasPrefix(typ, "[]") && !strings.HasPrefix(typ, "map[") &&
+		!strings.HasPrefix(typ, "*") && typ != "interface{}" {
+		return "*" + typ
+	}
+	return typ
+}
+
+// paramType method    返回接口参数或返回值类型，结构体使用指针.
+func (imp *oasImporter) paramType(s *oasSchema, name string, decls *[]string) string {
+	typ := imp.goType(s, name, decls)
+	if imp.structs[typ] {
+		return "*" + typ
+	}
+	return typ
+}
+
+// componentDecl method    生成组件数据结构的类型定义.
+func (imp *oasImporter) componentDecl(ref string, decls []string) []string {
+	s := imp.doc.schema(ref)
+	name := imp.typeNames[ref]
+	doc := schemaDoc(s)
+
+	// 字符串枚举复用枚举生成模板
+	if labels := s.stringEnum(); len(labels) > 0 {
+		e := columnEnum{Comment: doc}
+		if len(e.Comment) == 0 {
+			e.Comment = "枚举值."
+		}
+		e.TypeName = name
+		e.SnakeName = strcase.SnakeCase(name)
+		e.BaseType = "string"
+		e.CallerIdent = utils.GetFuncCallerIdent(name)
+		e.Values = enumConstValues(name, labels)
+		code, err := generateEnumCode([]columnEnum{e})
+		if err == nil {
+			return append(decls, strings.TrimSpace(string(code)))
+		}
+		logger.Warn("import enum %s failed: %s", name, err)
+	}
+
+	if imp.isInlineStruct(s) {
+		return imp.structDecl(name, doc, s, decls)
+	}
+
+	idx := len(decls)
+	decls = append(decls, "")
+	decls[idx] = typeComment(name, doc) + fmt.Sprintf("type %s %s", name, imp.goType(s, name+"Item", &decls))
+	return decls
+}
+
+// structDecl method    生成结构体类型定义，内联对象的类型定义追加在其后.
+func (imp *oasImporter) structDecl(name, doc string, s *oasSchema, decls []string) []string {
+	idx := len(decls)
+	decls = append(decls, "")
+
+	var bf strings.Builder
+	bf.WriteString(typeComment(name, doc))
+	bf.WriteString("type " + name + " struct {\n")
+	imp.writeFields(&bf, name, s, &decls, 0)
+	bf.WriteString("}")
+	decls[idx] = bf.String()
+	return decls
+}
+
+// writeFields method    写入数据结构的字段，allOf 中的结构体引用以匿名字段嵌入.
+func (imp *oasImporter) writeFields(bf *strings.Builder, parent string, s *oasSchema, decls *[]string, depth int) {
+	if depth > maxEmbedDepth {
+		return
+	}
+	for _, sub := range s.AllOf {
+		if len(sub.Ref) > 0 {
+			if typ := imp.refType(sub.Ref); imp.structs[typ] {
+				bf.WriteString("\t" + typ + "\n")
+			}
+			continue
+		}
+		imp.writeFields(bf, parent, sub, decls, depth+1)
+	}
+
+	required := make(map[string]bool)
+	for _, name := range s.Required {
+		required[name] = true
+	}
+	for _, key := range s.Properties.Keys {
+		prop := s.Properties.Values[key]
+		field := goIdentName(key)
+		typ := imp.fieldType(prop, parent+field, required[key], decls)
+
+		tag := fmt.Sprintf(`json:"%s,omitempty"`, key)
+		if required[key] {
+			tag = fmt.Sprintf(`json:"%s" binding:"required"`, key)
+		}
+		writeField(bf, field, typ, tag, schemaDoc(prop))
+	}
+}
+
+// services method    按接口分组生成 @service 接口定义.
+func (imp *oasImporter) services() (services []*importService, err error) {
+	tagTitles := make(map[string]string)
+	for _, tag := range imp.doc.Tags {
+		tagTitles[tag.Name] = firstLine(tag.Description)
+	}
+
+	byTag := make(map[string]*importService)
+	for _, path := range imp.doc.Paths.Keys {
+		item := imp.doc.Paths.Values[path]
+		if item == nil {
+			continue
+		}
+		for _, method := range importMethods {
+			op := item.operation(method)
+			if op == nil {
+				continue
+			}
+			tag := "default"
+			if len(op.Tags) > 0 {
+				tag = op.Tags[0]
+			}
+			svc := byTag[tag]
+			if svc == nil {
+				svc = &importService{
+					Name:        strings.Trim(importIdentRegex.ReplaceAllString(strcase.SnakeCase(tag), "_"), "_"),
+					Title:       tagTitles[tag],
+					methodNames: make(map[string]bool),
+				}
+				if len(svc.Name) == 0 {
+					svc.Name = fmt.Sprintf("service%d", len(services)+1)
+				}
+				svc.InterfaceName = imp.uniqueName(goIdentName(svc.Name) + "Service")
+				if len(svc.Title) == 0 {
+					svc.Title = tag
+				}
+				byTag[tag] = svc
+				services = append(services, svc)
+			}
+			svc.Methods = append(svc.Methods, imp.method(svc, path, method, item, op))
+		}
+	}
+
+	for _, svc := range services {
+		svc.resolveRoutes()
+	}
+	return services, nil
+}
+
+// method method    根据接口定义生成 @http 方法及请求/响应结构体.
+func (imp *oasImporter) method(svc *importService, path, httpMethod string, item *oasPathItem, op *oasOperation) *importMethod {
+	// 方法名
+	name := goIdentName(op.OperationID)
+	if len(op.OperationID) == 0 {
+		name = goIdentName(httpMethod + "_" + routeWords(path))
+	}
+	unique := name
+	for i := 2; svc.methodNames[unique]; i++ {
+		unique = fmt.Sprintf("%s%d", name, i)
+	}
+	svc.methodNames[unique] = true
+
+	m := &importMethod{
+		Name:       unique,
+		HttpMethod: httpMethod,
+		path:       importRoute(path),
+	}
+
+	// 注释
+	lines := docLines(op.Description)
+	switch {
+	case len(op.Summary) > 0:
+		m.Title = sanitizeDoc(op.Summary)
+	case len(lines) > 0:
+		m.Title, lines = lines[0], lines[1:]
+	default:
+		m.Title = unique
+	}
+	m.Doc = lines
+	if op.Deprecated {
+		m.Doc = append(m.Doc, "Deprecated: 该接口已废弃.")
+	}
+
+	m.Param = imp.requestParam(svc, unique, item, op)
+	m.Return = imp.responseType(svc, unique, op)
+	return m
+}
+
+// requestParam method    生成请求参数类型.
+func (imp *oasImporter) requestParam(svc *importService, methodName string, item *oasPathItem, op *oasOperation) string {
+	// 合并路径及接口参数，接口参数优先
+	var params []*oasParameter
+	index := make(map[string]int)
+	for _, p := range append(append([]*oasParameter{}, item.Parameters...), op.Parameters...) {
+		if p = imp.doc.parameter(p); p == nil {
+			continue
+		}
+		key := p.In + ":" + p.Name
+		if i, ok := index[key]; ok {
+			params[i] = p
+			continue
+		}
+		index[key] = len(params)
+		params = append(params, p)
+	}
+
+	var (
+		body     *oasSchema
+		formBody *oasSchema
+		fields   []*oasParameter
+	)
+	for _, p := range params {
+		switch p.In {
+		case "body":
+			body = p.Schema
+		case "path", "query", "header", "formData":
+			fields = append(fields, p)
+		}
+	}
+	if rb := imp.doc.requestBody(op.RequestBody); rb != nil {
+		contentType, schema := mediaSchema(rb.Content)
+		if strings.Contains(contentType, "form") && imp.isInlineStruct(schema) {
+			formBody = schema
+		} else {
+			body = schema
+		}
+	}
+
+	if len(fields) == 0 && formBody == nil {
+		if body == nil {
+			return ""
+		}
+		return imp.paramType(body, methodName+"Req", &svc.decls)
+	}
+
+	reqName := imp.uniqueName(methodName + "Req")
+	imp.structs[reqName] = true
+	idx := len(svc.decls)
+	svc.decls = append(svc.decls, "")
+
+	var bf strings.Builder
+	bf.WriteString(typeComment(reqName, methodName+" 请求参数."))
+	bf.WriteString("type " + reqName + " struct {\n")
+	for _, p := range fields {
+		field := goIdentName(p.Name)
+		typ := imp.goType(p.schema(), reqName+field, &svc.decls)
+		var tag string
+		switch p.In {
+		case "path":
+			tag = fmt.Sprintf(`uri:"%s" json:"%s"`, p.Name, p.Name)
+		case "query":
+			tag = fmt.Sprintf(`form:"%s" json:"%s,omitempty"`, p.Name, p.Name)
+		case "header":
+			tag = fmt.Sprintf(`header:"%s" json:"-"`, p.Name)
+		case "formData":
+			tag = fmt.Sprintf(`form:"%s" json:"%s,omitempty"`, p.Name, p.Name)
+		}
+		if p.Required || p.In == "path" {
+			tag += ` binding:"required"`
+		}
+		writeField(&bf, field, typ, tag, oneLine(p.Description))
+	}
+	if formBody != nil {
+		imp.writeFormFields(&bf, reqName, formBody, &svc.decls)
+	}
+	switch {
+	case body == nil:
+	case imp.isInlineStruct(body):
+		// 内联请求体直接展开到请求参数中
+		imp.writeFields(&bf, reqName, body, &svc.decls, 0)
+	default:
+		if typ := imp.goType(body, reqName+"Body", &svc.decls); imp.structs[typ] {
+			bf.WriteString("\t" + typ + "\n")
+		} else {
+			writeField(&bf, "Body", typ, `json:"body"`, "请求体")
+		}
+	}
+	bf.WriteString("}")
+	svc.decls[idx] = bf.String()
+	return "*" + reqName
+}
+
+// writeFormFields method    写入表单请求体字段，文件字段使用 *multipart.FileHeader.
+func (imp *oasImporter) writeFormFields(bf *strings.Builder, parent string, s *oasSchema, decls *[]string) {
+	required := make(map[string]bool)
+	for _, name := range s.Required {
+		required[name] = true
+	}
+	for _, key := range s.Properties.Keys {
+		prop := s.Properties.Values[key]
+		field := goIdentName(key)
+		typ := imp.goType(prop, parent+field, decls)
+		if typ == "[]byte" {
+			typ = "*multipart.FileHeader"
+		}
+		tag := fmt.Sprintf(`form:"%s" json:"%s,omitempty"`, key, key)
+		if required[key] {
+			tag += ` binding:"required"`
+		}
+		writeField(bf, field, typ, tag, schemaDoc(prop))
+	}
+}
+
+// responseType method    生成成功响应的返回值类型，优先使用最小的 2xx 状态码.
+func (imp *oasImporter) responseType(svc *importService, methodName string, op *oasOperation) string {
+	codes := append([]string{}, op.Responses.Keys...)
+	sort.Strings(codes)
+	code := "default"
+	for _, c := range codes {
+		if strings.HasPrefix(c, "2") {
+			code = c
+			break
+		}
+	}
+
+	resp := imp.doc.response(op.Responses.Values[code])
+	if resp == nil {
+		return ""
+	}
+	schema := resp.Schema
+	if schema == nil {
+		_, schema = mediaSchema(resp.Content)
+	}
+	if schema == nil {
+		return ""
+	}
+	if imp.isInlineStruct(schema) {
+		name := imp.uniqueName(methodName + "Resp")
+		imp.structs[name] = true
+		svc.decls = imp.structDecl(name, methodName+" 响应数据.", schema, svc.decls)
+		return "*" + name
+	}
+	return imp.paramType(schema, methodName+"Resp", &svc.decls)
+}
+
+// resolveRoutes method    计算服务的公共路由前缀，并将方法路由改为相对路由.
+func (svc *importService) resolveRoutes() {
+	var prefix []string
+	for i, m := range svc.Methods {
+		segs := strings.Split(strings.Trim(m.path, "/"), "/")
+		limit := len(segs) - 1
+		if i == 0 {
+			for _, seg := range segs[:max(limit, 0)] {
+				if strings.HasPrefix(seg, ":") || strings.HasPrefix(seg, "*") {
+					break
+				}
+				prefix = append(prefix, seg)
+			}
+			continue
+		}
+		n := 0
+		for n < len(prefix) && n < limit && prefix[n] == segs[n] {
+			n++
+		}
+		prefix = prefix[:n]
+	}
+
+	svc.Route = strings.Join(prefix, "/")
+	for _, m := range svc.Methods {
+		route := strings.Trim(strings.TrimPrefix(strings.Trim(m.path, "/"), svc.Route), "/")
+		if strings.HasSuffix(m.path, "/") && len(route) > 0 {
+			route += "/"
+		}
+		m.Route = route
+	}
+	if len(svc.Route) == 0 {
+		svc.Route = "/"
+	}
+}
+
+// importRoute function    将 OpenAPI 路径参数 {id} 转换为 gin 风格 :id.
+func importRoute(path string) string {
+	segs := strings.Split(path, "/")
+	for i, seg := range segs {
+		if strings.HasPrefix(seg, "{") && strings.HasSuffix(seg, "}") {
+			segs[i] = ":" + strings.Trim(seg, "{}")
+		}
+	}
+	return strings.Join(segs, "/")
+}
+
+// routeWords function    将路径转换为方法名单词，如 /users/{id} 转换为 users_by_id.
+func routeWords(path string) string {
+	var words []string
+	for _, seg := range strings.Split(strings.Trim(path, "/"), "/") {
+		if strings.HasPrefix(seg, "{") && strings.HasSuffix(seg, "}") {
+			words = append(words, "by", strings.Trim(seg, "{}"))
+			continue
+		}
+		words = append(words, seg)
+	}
+	return strings.Join(words, "_")
+}
+
+// goIdentName function    将名称转换为导出的 Go 标识符.
+func goIdentName(name string) string {
+	name = strings.Trim(importIdentRegex.ReplaceAllString(name, "_"), "_")
+	if len(name) == 0 {
+		return "Field"
+	}
+	return fmtFieldName(stringifyFirstChar(strcase.UpperCamelCase(name)))
+}
+
+// schemaDoc function    返回数据结构的单行描述.
+func schemaDoc(s *oasSchema) string {
+	if s == nil {
+		return ""
+	}
+	if len(s.Description) > 0 {
+		return oneLine(s.Description)
+	}
+	return oneLine(s.Title)
+}
+
+// typeComment function    生成类型注释.
+func typeComment(name, doc string) string {
+	if len(doc) == 0 {
+		return ""
+	}
+	return fmt.Sprintf("// %s %s\n", name, doc)
+}
+
+// writeField function    写入单个结构体字段.
+func writeField(bf *strings.Builder, name, typ, tag, doc string) {
+	bf.WriteString(fmt.Sprintf("\t%s %s `%s`", name, typ, tag))
+	if len(doc) > 0 {
+		bf.WriteString(" // " + doc)
+	}
+	bf.WriteString("\n")
+}
+
+// docLines function    将描述拆分为注释行，去除空行并转义注解.
+func docLines(text string) (lines []string) {
+	for _, line := range strings.Split(text, "\n") {
+		if line = sanitizeDoc(line); len(line) > 0 {
+			lines = append(lines, line)
+		}
+	}
+	return
+}
+
+// sanitizeDoc function    去除首尾空白，并去掉会被识别为注解的 @ 符号.
+func sanitizeDoc(line string) string {
+	return apiAnnotateRegex.ReplaceAllStringFunc(strings.TrimSpace(line), func(m string) string {
+		return strings.TrimPrefix(m, "@")
+	})
+}
+
+// oneLine function    将多行描述合并为单行.
+func oneLine(text string) string {
+	return strings.Join(docLines(text), " ")
+}
+
+// firstLine function    返回描述的第一行.
+func firstLine(text string) string {
+	if lines := docLines(text); len(lines) > 0 {
+		return lines[0]
+	}
+	return ""
+}
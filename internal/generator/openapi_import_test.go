@@ -0,0 +1,110 @@
+package generator
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/spelens-gud/gsus/internal/config"
+)
+
+// TestImportOpenAPI function    测试根据 OpenAPI 文档生成服务接口及数据结构.
+func TestImportOpenAPI(t *testing.T) {
+	spec, err := filepath.Abs(filepath.Join("testdata", "openapi"))
+	if err != nil {
+		t.Fatalf("Abs() error = %v", err)
+	}
+	// 导入处理会查找当前模块的依赖，在模块外执行以免修改 go.sum
+	t.Chdir(t.TempDir())
+
+	tests := []struct {
+		name string
+		spec string // testdata/openapi 下的文档，生成结果与同名目录下的 .golden 文件比较
+	}{
+		{name: "OpenAPI 3.x 引用及 allOf", spec: "petstore.v3.yaml"},
+		{name: "Swagger 2.0", spec: "petstore.v2.yaml"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := filepath.Join(t.TempDir(), "api")
+			if err := ImportOpenAPI(ImportOpenAPIConfig{Spec: filepath.Join(spec, tt.spec), Dir: dir}); err != nil {
+				t.Fatalf("ImportOpenAPI() error = %v", err)
+			}
+
+			goldenDir := filepath.Join(spec, strings.TrimSuffix(tt.spec, filepath.Ext(tt.spec)))
+			goldens, err := filepath.Glob(filepath.Join(goldenDir, "*.golden"))
+			if err != nil || len(goldens) == 0 {
+				t.Fatalf("Glob(%s) = %v, %v, want golden files", goldenDir, goldens, err)
+			}
+			generated, _ := filepath.Glob(filepath.Join(dir, "*.go"))
+			if len(generated) != len(goldens) {
+				t.Errorf("ImportOpenAPI() generated %d files, want %d", len(generated), len(goldens))
+			}
+			for _, golden := range goldens {
+				name := strings.TrimSuffix(filepath.Base(golden), ".golden")
+				want, _ := os.ReadFile(golden)
+				got, err := os.ReadFile(filepath.Join(dir, name))
+				if err != nil {
+					t.Errorf("ImportOpenAPI() missing %s: %v", name, err)
+					continue
+				}
+				if string(got) != string(want) {
+					t.Errorf("ImportOpenAPI() %s =\n%s\nwant\n%s", name, got, want)
+				}
+			}
+		})
+	}
+}
+
+// TestImportOpenAPI_Backup function    测试导入时已存在文件的备份及覆盖.
+func TestImportOpenAPI_Backup(t *testing.T) {
+	spec, err := filepath.Abs(filepath.Join("testdata", "openapi", "petstore.v2.yaml"))
+	if err != nil {
+		t.Fatalf("Abs() error = %v", err)
+	}
+	t.Chdir(t.TempDir())
+
+	tests := []struct {
+		name      string
+		overwrite bool
+		wantBak   bool
+	}{
+		{name: "备份原文件后覆盖", wantBak: true},
+		{name: "直接覆盖", overwrite: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := filepath.Join(t.TempDir(), "api")
+			path := filepath.Join(dir, "pet.go")
+			const existing = "package api\n\n// 手写的内容\n"
+			if err := os.MkdirAll(dir, 0o755); err != nil {
+				t.Fatalf("MkdirAll() error = %v", err)
+			}
+			if err := os.WriteFile(path, []byte(existing), 0o644); err != nil {
+				t.Fatalf("WriteFile() error = %v", err)
+			}
+
+			if err := ImportOpenAPI(ImportOpenAPIConfig{Spec: spec, Dir: dir, Overwrite: tt.overwrite}); err != nil {
+				t.Fatalf("ImportOpenAPI() error = %v", err)
+			}
+
+			got, _ := os.ReadFile(path)
+			if !strings.Contains(string(got), "type PetService interface") {
+				t.Errorf("ImportOpenAPI() pet.go = %q, want generated service", got)
+			}
+			bak, err := os.ReadFile(path + config.BackupSuffix)
+			if tt.wantBak && string(bak) != existing {
+				t.Errorf("ImportOpenAPI() backup = %q, %v, want %q", bak, err, existing)
+			}
+			if !tt.wantBak && !os.IsNotExist(err) {
+				t.Errorf("ImportOpenAPI() should not write backup, read error = %v", err)
+			}
+			if _, err = os.Stat(filepath.Join(dir, "openapi_types.go"+config.BackupSuffix)); !os.IsNotExist(err) {
+				t.Errorf("ImportOpenAPI() should not back up new file, stat error = %v", err)
+			}
+		})
+	}
+}
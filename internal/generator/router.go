@@ -9,10 +9,11 @@ import (
 
 	"github.com/spelens-gud/gsus/internal/parser"
 	template2 "github.com/spelens-gud/gsus/internal/template"
+	"github.com/spelens-gud/gsus/internal/utils"
 	"golang.org/x/sync/errgroup"
 )
 
-var defaultRouterTemplate = template.Must(template.New("svc").Parse(template2.DefaultHttpRouterTemplate))
+var defaultRouterTemplate = template.Must(utils.NewTemplate("svc").Parse(template2.DefaultHttpRouterTemplate))
 
 // GenApiRouterGroups function    生成接口路由组代码.
 func GenApiRouterGroups(apiGroups []parser.ApiGroup, baseDir string, opts ...func(options *parser.GenOptions)) (err error) {
@@ -0,0 +1,686 @@
+package generator
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"go/ast"
+	goparser "go/parser"
+	"go/token"
+	"net/http"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"text/template"
+
+	"github.com/spelens-gud/gsus/internal/errors"
+	"github.com/spelens-gud/gsus/internal/logger"
+	"github.com/spelens-gud/gsus/internal/parser"
+	template2 "github.com/spelens-gud/gsus/internal/template"
+	"github.com/spelens-gud/gsus/internal/utils"
+)
+
+// defaultSwaggerSuccess 默认成功响应格式.
+const defaultSwaggerSuccess = "200 {object} {{ .Response }}"
+
+const (
+	// swaggerVersion2 Swagger 2.0 文档版本.
+	swaggerVersion2 = "2.0"
+	// openapiVersion31 OpenAPI 3.1 文档版本.
+	openapiVersion31 = "3.1"
+)
+
+var (
+	swaggerMainTemplate   = template.Must(utils.NewTemplate("swagger_main").Parse(template2.DefaultSwaggerMainTemplate))
+	swaggerGeneralRegex   = regexp.MustCompile(`^@([\w.]+)\s+(.+)$`)
+	swaggerResponseRegex  = regexp.MustCompile(`^\s*([\w,\s]+?)\s+\{(\w+)\}\s+(\S+)(?:\s+"(.*)")?\s*$`)
+	swaggerHttpMethods    = map[string]bool{"get": true, "put": true, "post": true, "delete": true, "options": true, "head": true, "patch": true}
+	swaggerQueryMethods   = map[string]bool{"get": true, "delete": true, "head": true}
+	swaggerDefaultConsume = "application/json"
+)
+
+// SwaggerConfig struct    接口文档生成配置.
+type SwaggerConfig struct {
+	Path        string // 输出文件路径
+	MainApiPath string // 文档头部信息文件路径，不存在时自动生成
+	Success     string // 成功响应格式，{{ .Response }} 会填充为接口返回值类型
+	Failed      string // 失败响应格式
+	ProduceType string // 响应内容类型
+	Version     string // 文档版本（2.0/3.1），默认 2.0
+	TypeScope   string // 数据类型扫描范围，相对路径基于项目目录，默认为项目目录
+}
+
+// swaggerGeneral struct    文档头部通用信息.
+type swaggerGeneral struct {
+	Info     swaggerInfo // 基本信息
+	Host     string      // 服务地址
+	BasePath string      // 基础路径
+	Schemes  []string    // 协议列表
+}
+
+// swaggerInfo struct    文档基本信息.
+type swaggerInfo struct {
+	Title          string          `json:"title"`
+	Description    string          `json:"description,omitempty"`
+	TermsOfService string          `json:"termsOfService,omitempty"`
+	Contact        *swaggerContact `json:"contact,omitempty"`
+	License        *swaggerLicense `json:"license,omitempty"`
+	Version        string          `json:"version"`
+}
+
+// swaggerContact struct    联系人信息.
+type swaggerContact struct {
+	Name  string `json:"name,omitempty"`
+	URL   string `json:"url,omitempty"`
+	Email string `json:"email,omitempty"`
+}
+
+// swaggerLicense struct    许可证信息.
+type swaggerLicense struct {
+	Name string `json:"name,omitempty"`
+	URL  string `json:"url,omitempty"`
+}
+
+// swaggerTag struct    接口分组.
+type swaggerTag struct {
+	Name string `json:"name"`
+}
+
+// apiOperation struct    与文档版本无关的接口定义.
+type apiOperation struct {
+	Path        string        // 文档路径
+	Method      string        // 小写 HTTP 方法
+	Tag         string        // 接口分组
+	Summary     string        // 标题
+	Description string        // 描述
+	OperationID string        // 接口标识
+	Params      []apiParam    // path/query 参数
+	Body        *jsonSchema   // 请求体
+	Responses   []apiResponse // 响应列表
+}
+
+// apiParam struct    path/query 参数.
+type apiParam struct {
+	Name        string      // 参数名
+	In          string      // 参数位置
+	Description string      // 描述
+	Required    bool        // 是否必填
+	Schema      *jsonSchema // 参数类型
+}
+
+// apiResponse struct    接口响应.
+type apiResponse struct {
+	Code        string      // 状态码
+	Description string      // 描述
+	Schema      *jsonSchema // 响应数据结构
+}
+
+// swaggerResponseSpec struct    解析后的响应格式.
+type swaggerResponseSpec struct {
+	Codes       []string    // 状态码列表
+	Schema      *jsonSchema // 响应数据结构
+	Description string      // 响应描述
+}
+
+// GenSwagger function    根据 @service/@http 注解生成 Swagger 2.0 或 OpenAPI 3.1 接口文档.
+func GenSwagger(apiGroups []parser.ApiGroup, cfg SwaggerConfig) (err error) {
+	if len(cfg.Path) == 0 {
+		return errors.New(errors.ErrCodeConfig, "未指定 swagger 文档输出路径")
+	}
+	if len(cfg.Success) == 0 {
+		cfg.Success = defaultSwaggerSuccess
+	}
+	switch cfg.Version {
+	case "", "2", "2.0":
+		cfg.Version = swaggerVersion2
+	case "3.1", "3.1.0":
+		cfg.Version = openapiVersion31
+	default:
+		return errors.New(errors.ErrCodeConfig, fmt.Sprintf("不支持的文档版本: %s, 可选值为 2.0/3.1", cfg.Version))
+	}
+
+	general, err := loadSwaggerGeneral(cfg.MainApiPath)
+	if err != nil {
+		return err
+	}
+
+	index, err := newTypeIndex(cfg.TypeScope)
+	if err != nil {
+		return err
+	}
+	builder := newSchemaBuilder(index, "#/definitions/")
+	if cfg.Version == openapiVersion31 {
+		builder = newSchemaBuilder(index, "#/components/schemas/")
+		builder.draft2020 = true
+	}
+
+	successTemplate, err := utils.NewTemplate("success").Parse(cfg.Success)
+	if err != nil {
+		return errors.WrapWithCode(err, errors.ErrCodeTemplate, fmt.Sprintf("解析成功响应格式失败: %s", err))
+	}
+	failed, err := parseSwaggerResponse(builder, cfg.Failed)
+	if err != nil {
+		return err
+	}
+
+	var (
+		ops  []*apiOperation
+		tags []swaggerTag
+		seen = make(map[string]bool)
+	)
+	for _, group := range apiGroups {
+		tags = append(tags, swaggerTag{Name: group.GroupName})
+		builder.setScope(group.ServiceName)
+		for _, api := range group.Apis {
+			method := strings.ToLower(api.HttpMethod)
+			if method == "any" {
+				method = "post"
+			}
+			if !swaggerHttpMethods[method] {
+				logger.Warn("swagger skip [ %s %s ]: unsupported http method", api.HttpMethod, api.Route)
+				continue
+			}
+
+			op, err := newApiOperation(builder, successTemplate, failed, group, api, method)
+			if err != nil {
+				return err
+			}
+			if key := method + " " + op.Path; seen[key] {
+				logger.Warn("swagger duplicated route [ %s ], overwrite with %s.%s", key, group.GroupName, api.Handler)
+			} else {
+				seen[key] = true
+			}
+			ops = append(ops, op)
+		}
+	}
+	sort.Slice(tags, func(i, j int) bool {
+		return tags[i].Name < tags[j].Name
+	})
+
+	var doc interface{}
+	produce := produceMimeType(cfg.ProduceType)
+	if cfg.Version == openapiVersion31 {
+		doc = newOpenAPIDoc(general, produce, tags, ops, builder.definitions)
+	} else {
+		doc = newSwaggerDoc(general, produce, tags, ops, builder.definitions)
+	}
+
+	var bf bytes.Buffer
+	encoder := json.NewEncoder(&bf)
+	encoder.SetEscapeHTML(false)
+	encoder.SetIndent("", "    ")
+	if err = encoder.Encode(doc); err != nil {
+		return errors.WrapWithCode(err, errors.ErrCodeGenerate, fmt.Sprintf("序列化 swagger 文档失败: %s", err))
+	}
+	_ = os.MkdirAll(filepath.Dir(cfg.Path), 0775)
+	if err = os.WriteFile(cfg.Path, bf.Bytes(), 0664); err != nil {
+		return errors.WrapWithCode(err, errors.ErrCodeFile, fmt.Sprintf("写入 swagger 文档失败: %s", err))
+	}
+	logger.Info("generate swagger %s [ %s ]", cfg.Version, cfg.Path)
+	return nil
+}
+
+// newApiOperation function    根据接口定义生成与文档版本无关的接口描述.
+func newApiOperation(builder *schemaBuilder, successTemplate *template.Template, failed *swaggerResponseSpec,
+	group parser.ApiGroup, api *parser.Api, method string) (*apiOperation, error) {
+	route, pathParams := swaggerRoute(api.Route)
+	op := &apiOperation{
+		Path:        route,
+		Method:      method,
+		Tag:         group.GroupName,
+		Summary:     api.Title,
+		Description: swaggerDescription(api.Doc),
+		OperationID: group.GroupName + api.Handler,
+	}
+
+	// 路径参数
+	for _, name := range pathParams {
+		op.Params = append(op.Params, apiParam{
+			Name:     name,
+			In:       "path",
+			Required: true,
+			Schema:   &jsonSchema{Type: "string"},
+		})
+	}
+
+	// 请求参数
+	if param := apiParamType(api); len(param) > 0 {
+		if swaggerQueryMethods[method] {
+			op.Params = append(op.Params, queryParameters(builder, param, pathParams)...)
+		} else {
+			op.Body = builder.typeSchema(param)
+		}
+	}
+
+	// 响应
+	response := apiReturnType(api)
+	if len(response) == 0 {
+		response = "object"
+	}
+	success, err := utils.ExecuteTemplate(successTemplate, struct{ Response string }{Response: response})
+	if err != nil {
+		return nil, errors.WrapWithCode(err, errors.ErrCodeTemplate, fmt.Sprintf("渲染成功响应格式失败: %s", err))
+	}
+	spec, err := parseSwaggerResponse(builder, string(success))
+	if err != nil {
+		return nil, err
+	}
+	for _, s := range []*swaggerResponseSpec{spec, failed} {
+		if s == nil {
+			continue
+		}
+		for _, code := range s.Codes {
+			resp := apiResponse{Code: code, Description: s.Description, Schema: s.Schema}
+			if len(resp.Description) == 0 {
+				resp.Description = responseDescription(code)
+			}
+			op.Responses = append(op.Responses, resp)
+		}
+	}
+	return op, nil
+}
+
+// swaggerDoc struct    Swagger 2.0 文档.
+type swaggerDoc struct {
+	Swagger     string                                  `json:"swagger"`
+	Info        swaggerInfo                             `json:"info"`
+	Host        string                                  `json:"host,omitempty"`
+	BasePath    string                                  `json:"basePath,omitempty"`
+	Schemes     []string                                `json:"schemes,omitempty"`
+	Consumes    []string                                `json:"consumes,omitempty"`
+	Produces    []string                                `json:"produces,omitempty"`
+	Tags        []swaggerTag                            `json:"tags,omitempty"`
+	Paths       map[string]map[string]*swaggerOperation `json:"paths"`
+	Definitions map[string]*jsonSchema                  `json:"definitions,omitempty"`
+}
+
+// swaggerOperation struct    Swagger 2.0 单个接口定义.
+type swaggerOperation struct {
+	Tags        []string                    `json:"tags,omitempty"`
+	Summary     string                      `json:"summary,omitempty"`
+	Description string                      `json:"description,omitempty"`
+	OperationID string                      `json:"operationId,omitempty"`
+	Parameters  []*swaggerParameter         `json:"parameters,omitempty"`
+	Responses   map[string]*swaggerResponse `json:"responses"`
+}
+
+// swaggerParameter struct    Swagger 2.0 接口参数.
+type swaggerParameter struct {
+	Name             string      `json:"name"`
+	In               string      `json:"in"`
+	Description      string      `json:"description,omitempty"`
+	Required         bool        `json:"required,omitempty"`
+	Type             string      `json:"type,omitempty"`
+	Format           string      `json:"format,omitempty"`
+	Items            *jsonSchema `json:"items,omitempty"`
+	CollectionFormat string      `json:"collectionFormat,omitempty"`
+	Schema           *jsonSchema `json:"schema,omitempty"`
+}
+
+// swaggerResponse struct    Swagger 2.0 接口响应.
+type swaggerResponse struct {
+	Description string      `json:"description"`
+	Schema      *jsonSchema `json:"schema,omitempty"`
+}
+
+// newSwaggerDoc function    将接口定义组装为 Swagger 2.0 文档.
+func newSwaggerDoc(general swaggerGeneral, produce string, tags []swaggerTag, ops []*apiOperation,
+	definitions map[string]*jsonSchema) *swaggerDoc {
+	doc := &swaggerDoc{
+		Swagger:  swaggerVersion2,
+		Info:     general.Info,
+		Host:     general.Host,
+		BasePath: general.BasePath,
+		Schemes:  general.Schemes,
+		Consumes: []string{swaggerDefaultConsume},
+		Produces: []string{produce},
+		Tags:     tags,
+		Paths:    make(map[string]map[string]*swaggerOperation),
+	}
+	if len(definitions) > 0 {
+		doc.Definitions = definitions
+	}
+
+	for _, op := range ops {
+		sop := &swaggerOperation{
+			Tags:        []string{op.Tag},
+			Summary:     op.Summary,
+			Description: op.Description,
+			OperationID: op.OperationID,
+			Responses:   make(map[string]*swaggerResponse),
+		}
+		for _, param := range op.Params {
+			p := &swaggerParameter{
+				Name:        param.Name,
+				In:          param.In,
+				Description: param.Description,
+				Required:    param.Required,
+				Type:        param.Schema.Type,
+				Format:      param.Schema.Format,
+			}
+			if param.Schema.Type == "array" {
+				p.Items, p.CollectionFormat = param.Schema.Items, "multi"
+			}
+			sop.Parameters = append(sop.Parameters, p)
+		}
+		if op.Body != nil {
+			sop.Parameters = append(sop.Parameters, &swaggerParameter{
+				Name:     "body",
+				In:       "body",
+				Required: true,
+				Schema:   op.Body,
+			})
+		}
+		for _, resp := range op.Responses {
+			sop.Responses[resp.Code] = &swaggerResponse{Description: resp.Description, Schema: resp.Schema}
+		}
+
+		if doc.Paths[op.Path] == nil {
+			doc.Paths[op.Path] = make(map[string]*swaggerOperation)
+		}
+		doc.Paths[op.Path][op.Method] = sop
+	}
+	return doc
+}
+
+// queryParameters function    将请求参数结构体展开为 query 参数，仅保留基础类型及基础类型数组.
+func queryParameters(builder *schemaBuilder, param string, pathParams []string) (params []apiParam) {
+	expr, err := goparser.ParseExpr(param)
+	if err != nil {
+		return nil
+	}
+	file, pkg := builder.scopeContext()
+	decl := builder.index.lookup(expr, file, pkg)
+	if decl == nil {
+		return nil
+	}
+	st, ok := decl.Spec.Type.(*ast.StructType)
+	if !ok {
+		return nil
+	}
+
+	inPath := make(map[string]bool)
+	for _, name := range pathParams {
+		inPath[name] = true
+	}
+	for _, f := range builder.structFields(st, decl.File, decl.Pkg, 0) {
+		if uri, _ := parseTagName(f.Tag.Get("uri")); len(uri) > 0 {
+			continue
+		}
+		name, _ := parseTagName(f.Tag.Get("form"))
+		if len(name) == 0 {
+			name, _ = parseTagName(f.Tag.Get("json"))
+		}
+		if name == "-" {
+			continue
+		}
+		if len(name) == 0 {
+			name = f.Name
+		}
+		if inPath[name] {
+			continue
+		}
+
+		schema := builder.exprSchema(f.Expr, f.File, f.Pkg)
+		if !schema.isPrimitive() && !(schema.Type == "array" && schema.Items != nil && schema.Items.isPrimitive()) {
+			continue
+		}
+		// 未传递的 query 参数即为空值，无需声明 null
+		schema.Nullable = false
+		params = append(params, apiParam{
+			Name:        name,
+			In:          "query",
+			Description: f.Doc,
+			Required:    isRequiredField(f.Tag),
+			Schema:      schema,
+		})
+	}
+	return
+}
+
+// parseSwaggerResponse function    解析 swag 风格的响应格式，如 200 {object} object{data=service.User} "ok".
+func parseSwaggerResponse(builder *schemaBuilder, raw string) (*swaggerResponseSpec, error) {
+	if len(strings.TrimSpace(raw)) == 0 {
+		return nil, nil
+	}
+	match := swaggerResponseRegex.FindStringSubmatch(raw)
+	if len(match) != 5 {
+		return nil, errors.New(errors.ErrCodeConfig, fmt.Sprintf("响应格式无效: %s", raw))
+	}
+
+	spec := &swaggerResponseSpec{Description: match[4]}
+	for _, code := range strings.Split(match[1], ",") {
+		if code = strings.TrimSpace(code); len(code) > 0 {
+			spec.Codes = append(spec.Codes, code)
+		}
+	}
+	switch paramType := match[2]; paramType {
+	case "object":
+		spec.Schema = dataTypeSchema(builder, match[3])
+	case "array":
+		spec.Schema = &jsonSchema{Type: "array", Items: dataTypeSchema(builder, match[3])}
+	default:
+		spec.Schema = builder.primitiveSchema(paramType)
+		if spec.Schema == nil {
+			return nil, errors.New(errors.ErrCodeConfig, fmt.Sprintf("响应格式类型无效: %s", paramType))
+		}
+	}
+	return spec, nil
+}
+
+// dataTypeSchema function    解析响应数据类型，支持 object{key=type} 形式的组合类型.
+func dataTypeSchema(builder *schemaBuilder, dataType string) *jsonSchema {
+	dataType = strings.TrimSpace(dataType)
+	if strings.HasPrefix(dataType, "[]") {
+		return &jsonSchema{Type: "array", Items: dataTypeSchema(builder, dataType[2:])}
+	}
+
+	start := strings.Index(dataType, "{")
+	if start <= 0 || !strings.HasSuffix(dataType, "}") {
+		return builder.typeSchema(dataType)
+	}
+
+	override := &jsonSchema{Type: "object", Properties: make(map[string]*jsonSchema)}
+	for _, kv := range splitTopLevel(dataType[start+1:len(dataType)-1], ',') {
+		k, v, ok := strings.Cut(kv, "=")
+		if !ok {
+			continue
+		}
+		override.Properties[strings.TrimSpace(k)] = dataTypeSchema(builder, v)
+	}
+
+	base := dataType[:start]
+	if base == "object" {
+		return override
+	}
+	return &jsonSchema{AllOf: []*jsonSchema{builder.typeSchema(base), override}}
+}
+
+// splitTopLevel function    按分隔符切分字符串，忽略花括号内的分隔符.
+func splitTopLevel(s string, sep rune) (parts []string) {
+	depth, last := 0, 0
+	for i, c := range s {
+		switch c {
+		case '{':
+			depth++
+		case '}':
+			depth--
+		case sep:
+			if depth == 0 {
+				parts = append(parts, s[last:i])
+				last = i + 1
+			}
+		}
+	}
+	return append(parts, s[last:])
+}
+
+// loadSwaggerGeneral function    读取文档头部信息文件中的 swag 通用注解，文件不存在时自动生成.
+func loadSwaggerGeneral(mainApiPath string) (general swaggerGeneral, err error) {
+	modBase, _ := utils.GetModBase()
+	general.Info = swaggerInfo{Title: path.Base(modBase), Version: "1.0"}
+	if len(mainApiPath) == 0 {
+		return general, nil
+	}
+
+	if _, err = os.Stat(mainApiPath); os.IsNotExist(err) {
+		data := struct{ Package, Title string }{
+			Package: dirPackageName(filepath.Dir(mainApiPath)),
+			Title:   general.Info.Title,
+		}
+		logger.Info("generating swagger main api [ %s ]", mainApiPath)
+		if err = utils.ExecuteTemplateAndWrite(swaggerMainTemplate, data, mainApiPath); err != nil {
+			return general, errors.WrapWithCode(err, errors.ErrCodeGenerate, fmt.Sprintf("生成 swagger 头部信息文件失败: %s", err))
+		}
+	}
+
+	astFile, _, _, err := utils.ParseFileAst(mainApiPath)
+	if err != nil {
+		return general, errors.WrapWithCode(err, errors.ErrCodeParse, fmt.Sprintf("解析 swagger 头部信息文件失败: %s", err))
+	}
+	for _, cg := range astFile.Comments {
+		for _, cm := range cg.List {
+			match := swaggerGeneralRegex.FindStringSubmatch(strings.TrimSpace(strings.TrimPrefix(cm.Text, "//")))
+			if len(match) != 3 {
+				continue
+			}
+			general.apply(match[1], strings.TrimSpace(match[2]))
+		}
+	}
+	return general, nil
+}
+
+// apply method    写入单条 swag 通用注解.
+func (g *swaggerGeneral) apply(key, value string) {
+	contact := func() *swaggerContact {
+		if g.Info.Contact == nil {
+			g.Info.Contact = &swaggerContact{}
+		}
+		return g.Info.Contact
+	}
+	license := func() *swaggerLicense {
+		if g.Info.License == nil {
+			g.Info.License = &swaggerLicense{}
+		}
+		return g.Info.License
+	}
+	switch strings.ToLower(key) {
+	case "title":
+		g.Info.Title = value
+	case "version":
+		g.Info.Version = value
+	case "description":
+		if len(g.Info.Description) > 0 {
+			value = g.Info.Description + "\n" + value
+		}
+		g.Info.Description = value
+	case "termsofservice":
+		g.Info.TermsOfService = value
+	case "contact.name":
+		contact().Name = value
+	case "contact.url":
+		contact().URL = value
+	case "contact.email":
+		contact().Email = value
+	case "license.name":
+		license().Name = value
+	case "license.url":
+		license().URL = value
+	case "host":
+		g.Host = value
+	case "basepath":
+		g.BasePath = value
+	case "schemes":
+		g.Schemes = strings.Fields(value)
+	}
+}
+
+// dirPackageName function    获取目录下已有 Go 文件的包名，没有时使用目录名.
+func dirPackageName(dir string) string {
+	entries, _ := os.ReadDir(dir)
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasSuffix(entry.Name(), ".go") || strings.HasSuffix(entry.Name(), "_test.go") {
+			continue
+		}
+		f, err := goparser.ParseFile(token.NewFileSet(), filepath.Join(dir, entry.Name()), nil, goparser.PackageClauseOnly)
+		if err == nil {
+			return f.Name.Name
+		}
+	}
+	return strings.ReplaceAll(filepath.Base(dir), "-", "_")
+}
+
+// swaggerRoute function    将 gin 风格路由转换为文档路径，并返回路径参数.
+func swaggerRoute(route string) (string, []string) {
+	var params []string
+	segments := strings.Split(strings.Trim(route, `"`), "/")
+	for i, seg := range segments {
+		if strings.HasPrefix(seg, ":") || strings.HasPrefix(seg, "*") {
+			params = append(params, seg[1:])
+			segments[i] = "{" + seg[1:] + "}"
+		}
+	}
+	return "/" + strings.Join(segments, "/"), params
+}
+
+// swaggerDescription function    将接口文档注释转换为描述.
+func swaggerDescription(doc []string) string {
+	var lines []string
+	for _, line := range doc {
+		line = strings.TrimSpace(strings.TrimPrefix(line, "//"))
+		if strings.HasPrefix(line, "@") {
+			continue
+		}
+		lines = append(lines, line)
+	}
+	return strings.TrimSpace(strings.Join(lines, "\n"))
+}
+
+// apiParamType function    返回接口的请求参数类型.
+func apiParamType(api *parser.Api) string {
+	for _, p := range api.Params {
+		if p != "context.Context" {
+			return p
+		}
+	}
+	return ""
+}
+
+// apiReturnType function    返回接口的响应类型.
+func apiReturnType(api *parser.Api) string {
+	for _, r := range api.Returns {
+		if r != "error" {
+			return strings.TrimPrefix(r, "*")
+		}
+	}
+	return ""
+}
+
+// produceMimeType function    将响应类型简写转换为 MIME 类型.
+func produceMimeType(produceType string) string {
+	switch produceType {
+	case "", "json":
+		return "application/json"
+	case "xml":
+		return "application/xml"
+	case "plain", "text":
+		return "text/plain"
+	case "html":
+		return "text/html"
+	}
+	return produceType
+}
+
+// responseDescription function    返回状态码的默认描述.
+func responseDescription(code string) string {
+	if c, err := strconv.Atoi(code); err == nil {
+		if text := http.StatusText(c); len(text) > 0 {
+			return text
+		}
+	}
+	return code
+}
@@ -0,0 +1,577 @@
+package generator
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"go/ast"
+	goparser "go/parser"
+	"go/token"
+	"os"
+	"path"
+	"path/filepath"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/spelens-gud/gsus/internal/errors"
+	"github.com/spelens-gud/gsus/internal/utils"
+)
+
+// maxEmbedDepth 匿名字段展开的最大深度.
+const maxEmbedDepth = 8
+
+// jsonSchema struct    接口文档中的数据结构定义.
+type jsonSchema struct {
+	Ref                  string                 `json:"$ref,omitempty"`
+	Type                 string                 `json:"type,omitempty"`
+	Format               string                 `json:"format,omitempty"`
+	Description          string                 `json:"description,omitempty"`
+	Items                *jsonSchema            `json:"items,omitempty"`
+	Properties           map[string]*jsonSchema `json:"properties,omitempty"`
+	AdditionalProperties *jsonSchema            `json:"additionalProperties,omitempty"`
+	Required             []string               `json:"required,omitempty"`
+	AllOf                []*jsonSchema          `json:"allOf,omitempty"`
+	AnyOf                []*jsonSchema          `json:"anyOf,omitempty"`
+	ContentEncoding      string                 `json:"contentEncoding,omitempty"`
+	Nullable             bool                   `json:"-"` // 可为空，仅 JSON Schema 2020-12 输出
+}
+
+// MarshalJSON method    序列化数据结构，可为空的类型输出为 type: [T, "null"]，可为空的引用输出为 anyOf.
+func (s jsonSchema) MarshalJSON() ([]byte, error) {
+	type alias jsonSchema
+	switch {
+	case !s.Nullable:
+		return marshalNoEscape(alias(s))
+	case len(s.Ref) > 0:
+		return marshalNoEscape(alias{
+			Description: s.Description,
+			AnyOf:       []*jsonSchema{{Ref: s.Ref}, {Type: "null"}},
+		})
+	default:
+		return marshalNoEscape(struct {
+			alias
+			Type []string `json:"type,omitempty"`
+		}{alias: alias(s), Type: []string{s.Type, "null"}})
+	}
+}
+
+// marshalNoEscape function    序列化 JSON，不转义 HTML 字符.
+func marshalNoEscape(v interface{}) ([]byte, error) {
+	var bf bytes.Buffer
+	encoder := json.NewEncoder(&bf)
+	encoder.SetEscapeHTML(false)
+	if err := encoder.Encode(v); err != nil {
+		return nil, err
+	}
+	return bytes.TrimRight(bf.Bytes(), "\n"), nil
+}
+
+// isPrimitive method    判断是否为基础类型，基础类型可作为 query/path 参数.
+func (s *jsonSchema) isPrimitive() bool {
+	return len(s.Ref) == 0 && len(s.Type) > 0 && s.Type != "object" && s.Type != "array"
+}
+
+// typeIndex struct    项目内数据类型索引，用于解析接口参数及返回值的结构定义.
+type typeIndex struct {
+	pkgs   map[string]*typePkg   // 导入路径 -> 包
+	byName map[string][]*typePkg // 包名 -> 包
+}
+
+// typePkg struct    索引中的包.
+type typePkg struct {
+	Name  string               // 包名
+	Path  string               // 导入路径
+	Types map[string]*typeDecl // 类型定义
+}
+
+// typeDecl struct    索引中的类型定义.
+type typeDecl struct {
+	Name string            // 类型名
+	Spec *ast.TypeSpec     // 类型定义节点
+	Doc  *ast.CommentGroup // 类型注释
+	File *ast.File         // 所在文件，用于解析导入
+	Fset *token.FileSet    // 所在文件的位置信息
+	Pkg  *typePkg          // 所在包
+}
+
+// position method    返回类型定义所在的文件及行号.
+func (d *typeDecl) position() string {
+	pos := d.Fset.Position(d.Spec.Pos())
+	return fmt.Sprintf("%s:%d", pos.Filename, pos.Line)
+}
+
+// newTypeIndex function    扫描范围内的 Go 文件并建立类型索引.
+func newTypeIndex(scope string) (*typeIndex, error) {
+	if len(scope) == 0 {
+		scope = "./"
+	}
+	projectDir, err := utils.GetProjectDir()
+	if err != nil {
+		return nil, errors.WrapWithCode(err, errors.ErrCodeConfig, fmt.Sprintf("获取项目目录失败: %s", err))
+	}
+	modBase, err := utils.GetModBase()
+	if err != nil {
+		return nil, errors.WrapWithCode(err, errors.ErrCodeConfig, fmt.Sprintf("获取模块路径失败: %s", err))
+	}
+	if err = utils.FixFilepathByProjectDir(&scope); err != nil {
+		return nil, errors.WrapWithCode(err, errors.ErrCodeFile, fmt.Sprintf("修正文件路径失败: %s", err))
+	}
+
+	idx := &typeIndex{
+		pkgs:   make(map[string]*typePkg),
+		byName: make(map[string][]*typePkg),
+	}
+	err = filepath.Walk(scope, func(fp string, info os.FileInfo, err error) error {
+		if err != nil {
+			return nil
+		}
+		if info.IsDir() {
+			name := info.Name()
+			if fp != scope && (name == "vendor" || name == "testdata" || strings.HasPrefix(name, ".")) {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !strings.HasSuffix(fp, ".go") || strings.HasSuffix(fp, "_test.go") {
+			return nil
+		}
+		fset := token.NewFileSet()
+		astFile, err := goparser.ParseFile(fset, fp, nil, goparser.ParseComments)
+		if err != nil {
+			return nil
+		}
+		rel, err := filepath.Rel(projectDir, filepath.Dir(fp))
+		if err != nil {
+			return nil
+		}
+		importPath := modBase
+		if rel != "." {
+			importPath = path.Join(modBase, filepath.ToSlash(rel))
+		}
+		idx.add(importPath, fset, astFile)
+		return nil
+	})
+	if err != nil {
+		return nil, errors.WrapWithCode(err, errors.ErrCodeFile, fmt.Sprintf("扫描类型定义失败: %s", err))
+	}
+	return idx, nil
+}
+
+// add method    将文件中的类型定义加入索引.
+func (idx *typeIndex) add(importPath string, fset *token.FileSet, astFile *ast.File) {
+	pkg, ok := idx.pkgs[importPath]
+	if !ok {
+		pkg = &typePkg{
+			Name:  astFile.Name.Name,
+			Path:  importPath,
+			Types: make(map[string]*typeDecl),
+		}
+		idx.pkgs[importPath] = pkg
+		idx.byName[pkg.Name] = append(idx.byName[pkg.Name], pkg)
+	}
+	for _, decl := range astFile.Decls {
+		genDecl, ok := decl.(*ast.GenDecl)
+		if !ok || genDecl.Tok != token.TYPE {
+			continue
+		}
+		for _, spec := range genDecl.Specs {
+			ts := spec.(*ast.TypeSpec)
+			doc := ts.Doc
+			if len(genDecl.Specs) == 1 && genDecl.Doc != nil {
+				doc = genDecl.Doc
+			}
+			pkg.Types[ts.Name.Name] = &typeDecl{
+				Name: ts.Name.Name,
+				Spec: ts,
+				Doc:  doc,
+				File: astFile,
+				Fset: fset,
+				Pkg:  pkg,
+			}
+		}
+	}
+}
+
+// interfaceDecl method    按 包名.类型名 查找接口类型定义，存在多个时取导入路径最小的.
+func (idx *typeIndex) interfaceDecl(name string) (found *typeDecl) {
+	pkgName, typeName, ok := strings.Cut(name, ".")
+	if !ok {
+		return nil
+	}
+	for _, pkg := range idx.byName[pkgName] {
+		decl := pkg.Types[typeName]
+		if decl == nil {
+			continue
+		}
+		if _, ok := decl.Spec.Type.(*ast.InterfaceType); !ok {
+			continue
+		}
+		if found == nil || pkg.Path < found.Pkg.Path {
+			found = decl
+		}
+	}
+	return
+}
+
+// lookup method    查找类型表达式对应的类型定义，file 与 pkg 为空时按包名查找.
+func (idx *typeIndex) lookup(expr ast.Expr, file *ast.File, pkg *typePkg) *typeDecl {
+	switch t := expr.(type) {
+	case *ast.StarExpr:
+		return idx.lookup(t.X, file, pkg)
+	case *ast.Ident:
+		if pkg != nil {
+			return pkg.Types[t.Name]
+		}
+	case *ast.SelectorExpr:
+		x, ok := t.X.(*ast.Ident)
+		if !ok {
+			return nil
+		}
+		if file != nil {
+			for _, imp := range file.Imports {
+				importPath, _ := strconv.Unquote(imp.Path.Value)
+				name := path.Base(importPath)
+				if imp.Name != nil {
+					name = imp.Name.Name
+				}
+				if name != x.Name {
+					continue
+				}
+				if p := idx.pkgs[importPath]; p != nil {
+					return p.Types[t.Sel.Name]
+				}
+				return nil
+			}
+		}
+		for _, p := range idx.byName[x.Name] {
+			if decl := p.Types[t.Sel.Name]; decl != nil {
+				return decl
+			}
+		}
+	}
+	return nil
+}
+
+// schemaBuilder struct    将 Go 类型转换为接口文档数据结构.
+type schemaBuilder struct {
+	index       *typeIndex
+	refPrefix   string                 // 引用前缀，如 #/definitions/
+	definitions map[string]*jsonSchema // 已生成的结构定义
+	names       map[*typeDecl]string   // 结构体类型对应的定义名称
+	owners      map[string]*typeDecl   // 定义名称对应的结构体类型，用于检测同名包的冲突
+	inlining    map[*typeDecl]bool     // 正在展开的非结构体类型
+	draft2020   bool                   // 按 JSON Schema 2020-12（OpenAPI 3.1）输出
+	scope       *typeDecl              // 当前服务接口，按其所在文件的导入解析顶层类型
+}
+
+// newSchemaBuilder function    创建数据结构构建器.
+func newSchemaBuilder(index *typeIndex, refPrefix string) *schemaBuilder {
+	return &schemaBuilder{
+		index:       index,
+		refPrefix:   refPrefix,
+		definitions: make(map[string]*jsonSchema),
+		names:       make(map[*typeDecl]string),
+		owners:      make(map[string]*typeDecl),
+		inlining:    make(map[*typeDecl]bool),
+	}
+}
+
+// typeSchema method    解析类型字符串（如 *service.UserResp）并返回数据结构.
+func (b *schemaBuilder) typeSchema(typ string) *jsonSchema {
+	// 顶层参数及返回值的指针不影响文档结构
+	typ = strings.TrimLeft(strings.TrimSpace(typ), "*")
+	if s := b.primitiveSchema(typ); s != nil {
+		return s
+	}
+	expr, err := goparser.ParseExpr(typ)
+	if err != nil {
+		return &jsonSchema{Type: "object"}
+	}
+	file, pkg := b.scopeContext()
+	return b.exprSchema(expr, file, pkg)
+}
+
+// setScope method    设置当前服务接口，未找到时按包名解析顶层类型.
+func (b *schemaBuilder) setScope(serviceName string) {
+	b.scope = b.index.interfaceDecl(serviceName)
+}
+
+// scopeContext method    返回当前服务接口所在的文件及包.
+func (b *schemaBuilder) scopeContext() (*ast.File, *typePkg) {
+	if b.scope == nil {
+		return nil, nil
+	}
+	return b.scope.File, b.scope.Pkg
+}
+
+// exprSchema method    将类型表达式转换为数据结构.
+func (b *schemaBuilder) exprSchema(expr ast.Expr, file *ast.File, pkg *typePkg) *jsonSchema {
+	switch t := expr.(type) {
+	case *ast.StarExpr:
+		s := b.exprSchema(t.X, file, pkg)
+		s.Nullable = b.draft2020 && (len(s.Ref) > 0 || len(s.Type) > 0)
+		return s
+	case *ast.ParenExpr:
+		return b.exprSchema(t.X, file, pkg)
+	case *ast.Ident:
+		if s := b.primitiveSchema(t.Name); s != nil {
+			return s
+		}
+	case *ast.SelectorExpr:
+		if x, ok := t.X.(*ast.Ident); ok {
+			name := x.Name + "." + t.Sel.Name
+			if s := wellKnownSchema(name); s != nil {
+				s.Nullable = b.draft2020 && strings.HasPrefix(name, "sql.Null")
+				return s
+			}
+		}
+	case *ast.ArrayType:
+		if ident, ok := t.Elt.(*ast.Ident); ok && ident.Name == "byte" {
+			if b.draft2020 {
+				return &jsonSchema{Type: "string", ContentEncoding: "base64"}
+			}
+			return &jsonSchema{Type: "string", Format: "byte"}
+		}
+		return &jsonSchema{Type: "array", Items: b.exprSchema(t.Elt, file, pkg)}
+	case *ast.MapType:
+		return &jsonSchema{Type: "object", AdditionalProperties: b.exprSchema(t.Value, file, pkg)}
+	case *ast.StructType:
+		return b.structSchema(t, file, pkg)
+	default:
+		return &jsonSchema{Type: "object"}
+	}
+
+	if decl := b.index.lookup(expr, file, pkg); decl != nil {
+		return b.declSchema(decl)
+	}
+	return &jsonSchema{Type: "object"}
+}
+
+// declSchema method    返回类型定义的数据结构，结构体类型生成引用.
+func (b *schemaBuilder) declSchema(decl *typeDecl) *jsonSchema {
+	if decl.Spec.TypeParams != nil {
+		return &jsonSchema{Type: "object"}
+	}
+	st, ok := decl.Spec.Type.(*ast.StructType)
+	if !ok {
+		// 非结构体类型直接展开为底层类型，自引用类型退化为 object
+		if b.inlining[decl] {
+			return &jsonSchema{Type: "object"}
+		}
+		b.inlining[decl] = true
+		defer delete(b.inlining, decl)
+		return b.exprSchema(decl.Spec.Type, decl.File, decl.Pkg)
+	}
+
+	name := b.definitionName(decl)
+	if _, ok := b.definitions[name]; !ok {
+		// 先占位，防止自引用结构体无限递归
+		def := &jsonSchema{}
+		b.definitions[name] = def
+		*def = *b.structSchema(st, decl.File, decl.Pkg)
+	}
+	return &jsonSchema{Ref: b.refPrefix + name}
+}
+
+// definitionName method    返回结构体类型的定义名称，默认为 包名.类型名.
+// 不同导入路径的同名包存在同名类型时，依次加上导入路径的上级目录区分，如 v1.model.User.
+func (b *schemaBuilder) definitionName(decl *typeDecl) string {
+	if name, ok := b.names[decl]; ok {
+		return name
+	}
+	base := decl.Pkg.Name + "." + decl.Name
+	name := base
+	dirs := strings.Split(path.Dir(decl.Pkg.Path), "/")
+	for i, n := len(dirs)-1, 2; ; {
+		if owner, ok := b.owners[name]; !ok || owner == decl {
+			break
+		}
+		if i >= 0 && dirs[i] != "." && len(dirs[i]) > 0 {
+			base = dirs[i] + "." + base
+			name, i = base, i-1
+			continue
+		}
+		// 导入路径无法区分时追加序号
+		name, n = fmt.Sprintf("%s%d", base, n), n+1
+	}
+	b.names[decl], b.owners[name] = name, decl
+	return name
+}
+
+// structField struct    展开匿名字段后的结构体字段.
+type structField struct {
+	Name string            // Go 字段名
+	Tag  reflect.StructTag // 字段标签
+	Expr ast.Expr          // 字段类型
+	Doc  string            // 字段注释
+	File *ast.File         // 所在文件
+	Pkg  *typePkg          // 所在包
+}
+
+// structFields method    列出结构体的导出字段，未指定 json 名称的匿名结构体字段会被展开.
+func (b *schemaBuilder) structFields(st *ast.StructType, file *ast.File, pkg *typePkg, depth int) (fields []structField) {
+	if st.Fields == nil {
+		return
+	}
+	for _, field := range st.Fields.List {
+		var tag reflect.StructTag
+		if field.Tag != nil {
+			tag = reflect.StructTag(strings.Trim(field.Tag.Value, "`"))
+		}
+		doc := fieldDoc(field)
+
+		if len(field.Names) == 0 {
+			jsonName, _ := parseTagName(tag.Get("json"))
+			if len(jsonName) == 0 && depth < maxEmbedDepth {
+				if decl := b.index.lookup(field.Type, file, pkg); decl != nil {
+					if est, ok := decl.Spec.Type.(*ast.StructType); ok {
+						fields = append(fields, b.structFields(est, decl.File, decl.Pkg, depth+1)...)
+						continue
+					}
+				}
+			}
+			name := embeddedName(field.Type)
+			if len(name) == 0 || !ast.IsExported(name) {
+				continue
+			}
+			fields = append(fields, structField{Name: name, Tag: tag, Expr: field.Type, Doc: doc, File: file, Pkg: pkg})
+			continue
+		}
+
+		for _, ident := range field.Names {
+			if !ident.IsExported() {
+				continue
+			}
+			fields = append(fields, structField{Name: ident.Name, Tag: tag, Expr: field.Type, Doc: doc, File: file, Pkg: pkg})
+		}
+	}
+	return
+}
+
+// structSchema method    将结构体转换为对象数据结构.
+func (b *schemaBuilder) structSchema(st *ast.StructType, file *ast.File, pkg *typePkg) *jsonSchema {
+	s := &jsonSchema{Type: "object", Properties: make(map[string]*jsonSchema)}
+	for _, f := range b.structFields(st, file, pkg, 0) {
+		name, _ := parseTagName(f.Tag.Get("json"))
+		if name == "-" {
+			continue
+		}
+		if len(name) == 0 {
+			name = f.Name
+		}
+		prop := b.exprSchema(f.Expr, f.File, f.Pkg)
+		if len(prop.Ref) == 0 && len(f.Doc) > 0 {
+			prop.Description = f.Doc
+		}
+		s.Properties[name] = prop
+		if isRequiredField(f.Tag) {
+			s.Required = append(s.Required, name)
+		}
+	}
+	return s
+}
+
+// primitiveSchema method    返回基础类型对应的数据结构，按输出版本调整文件类型.
+func (b *schemaBuilder) primitiveSchema(name string) *jsonSchema {
+	s := primitiveSchema(name)
+	if s != nil && s.Type == "file" && b.draft2020 {
+		return &jsonSchema{Type: "string", Format: "binary"}
+	}
+	return s
+}
+
+// primitiveSchema function    返回 Go 基础类型及文档基础类型对应的数据结构，非基础类型返回 nil.
+func primitiveSchema(name string) *jsonSchema {
+	switch name {
+	case "string":
+		return &jsonSchema{Type: "string"}
+	case "bool", "boolean":
+		return &jsonSchema{Type: "boolean"}
+	case "int", "int8", "int16", "uint", "uint8", "uint16", "byte", "integer":
+		return &jsonSchema{Type: "integer"}
+	case "int32", "uint32", "rune":
+		return &jsonSchema{Type: "integer", Format: "int32"}
+	case "int64", "uint64":
+		return &jsonSchema{Type: "integer", Format: "int64"}
+	case "float32":
+		return &jsonSchema{Type: "number", Format: "float"}
+	case "float64", "number":
+		return &jsonSchema{Type: "number", Format: "double"}
+	case "object", "any", "interface{}":
+		return &jsonSchema{Type: "object"}
+	case "file":
+		return &jsonSchema{Type: "file"}
+	}
+	return nil
+}
+
+// wellKnownSchema function    返回常用标准库类型对应的数据结构.
+func wellKnownSchema(name string) *jsonSchema {
+	switch name {
+	case "time.Time", "sql.NullTime":
+		return &jsonSchema{Type: "string", Format: "date-time"}
+	case "time.Duration", "sql.NullInt64":
+		return &jsonSchema{Type: "integer", Format: "int64"}
+	case "sql.NullInt32", "sql.NullInt16":
+		return &jsonSchema{Type: "integer", Format: "int32"}
+	case "sql.NullString":
+		return &jsonSchema{Type: "string"}
+	case "sql.NullBool":
+		return &jsonSchema{Type: "boolean"}
+	case "sql.NullFloat64":
+		return &jsonSchema{Type: "number", Format: "double"}
+	case "json.RawMessage":
+		return &jsonSchema{Type: "object"}
+	}
+	return nil
+}
+
+// parseTagName function    解析标签值中的名称及 omitempty 选项.
+func parseTagName(value string) (name string, omitempty bool) {
+	parts := strings.Split(value, ",")
+	for _, opt := range parts[1:] {
+		if opt == "omitempty" {
+			omitempty = true
+		}
+	}
+	return parts[0], omitempty
+}
+
+// isRequiredField function    根据 binding/validate 标签判断字段是否必填.
+func isRequiredField(tag reflect.StructTag) bool {
+	for _, key := range []string{"binding", "validate"} {
+		for _, rule := range strings.Split(tag.Get(key), ",") {
+			if strings.TrimSpace(rule) == "required" {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// fieldDoc function    返回字段的文档或行尾注释.
+func fieldDoc(field *ast.Field) string {
+	for _, cg := range []*ast.CommentGroup{field.Doc, field.Comment} {
+		if cg == nil {
+			continue
+		}
+		if text := strings.TrimSpace(cg.Text()); len(text) > 0 {
+			return text
+		}
+	}
+	return ""
+}
+
+// embeddedName function    返回匿名字段的字段名.
+func embeddedName(expr ast.Expr) string {
+	switch t := expr.(type) {
+	case *ast.StarExpr:
+		return embeddedName(t.X)
+	case *ast.Ident:
+		return t.Name
+	case *ast.SelectorExpr:
+		return t.Sel.Name
+	}
+	return ""
+}
@@ -1,13 +1,19 @@
 package generator
 
 import (
+	"bytes"
 	"fmt"
 	"go/ast"
+	"os"
+	"path"
 	"path/filepath"
+	"strconv"
 	"strings"
+	"text/template"
 
 	"github.com/spelens-gud/gsus/internal/config"
 	"github.com/spelens-gud/gsus/internal/errors"
+	"github.com/spelens-gud/gsus/internal/logger"
 	"github.com/spelens-gud/gsus/internal/utils"
 	"github.com/stoewer/go-strcase"
 )
@@ -16,7 +22,13 @@ type TemplateConfig struct {
 	ModelName string
 	ModelPath string
 	Overwrite bool
-	Templates []config.Template
+	Templates []TemplateFile
+}
+
+// TemplateFile struct    单个模板的生成配置.
+type TemplateFile struct {
+	config.Template
+	Content *template.Template // 已加载的代码模板
 }
 type Template struct {
 	Name           string
@@ -29,11 +41,25 @@ type Template struct {
 	StructName     string
 	CallerIdent    string
 	Fields         []Field
+	PrimaryKey     Field // 主键字段，gorm 标签未声明主键时使用名为 ID 的字段，均不存在时为 int 类型的 ID
 }
+
+// Field struct    模型字段，字段元数据读取自 db2struct 生成的结构体标签.
 type Field struct {
-	Name    string
-	Type    string
-	Comment string
+	Name          string            // 字段名
+	Type          string            // 字段类型
+	Comment       string            // 字段注释，无行尾注释时使用 gorm 标签中的 comment
+	Column        string            // 数据库列名，取自 gorm 标签 column，未设置时为字段名的蛇形形式
+	SQLType       string            // 数据库列类型，取自 gorm 标签 type
+	Default       string            // 数据库默认值，取自 gorm 标签 default
+	Nullable      bool              // 是否可为空，字段类型为指针或 sql.Null* 类型，或 gorm 标签声明了 type 但未声明 not null 时为 true
+	PrimaryKey    bool              // 是否为主键
+	AutoIncrement bool              // 是否自增
+	Indexes       []string          // 所属的普通索引
+	UniqueIndexes []string          // 所属的唯一索引
+	JSON          string            // json 标签中的名称，未设置时为字段名，为 - 时表示不参与序列化
+	Tags          map[string]string // 所有结构体标签，键为标签名，可选标签使用 {{ index .Tags "validate" }} 获取
+	Gorm          map[string]string // 解析后的 gorm 标签，键为标签项名，无值的标签项值为空字符串
 }
 
 func GenTemplate(cfg TemplateConfig, mainConfig config.Option) (err error) {
@@ -43,10 +69,16 @@ func GenTemplate(cfg TemplateConfig, mainConfig config.Option) (err error) {
 	}
 
 	templateStruct, err := parseTemplates(cfg.ModelName, tableBytes, astFile)
-	if err != nil || templateStruct == nil {
+	if err != nil {
 		return
 	}
+	if templateStruct == nil {
+		return errors.New(errors.ErrCodeTemplate, fmt.Sprintf("模型文件中未找到结构体 %s", strcase.UpperCamelCase(cfg.ModelName)))
+	}
 
+	if templateStruct.ServicePkgPath, err = servicePkgPath(mainConfig.Impls); err != nil {
+		return
+	}
 	if len(templateStruct.ServicePkgPath) == 0 {
 		return errors.New(errors.ErrCodeTemplate, "服务包配置未找到，请检查实现配置")
 	}
@@ -57,10 +89,97 @@ func GenTemplate(cfg TemplateConfig, mainConfig config.Option) (err error) {
 		if cfg.Overwrite {
 			temp.Overwrite = cfg.Overwrite
 		}
+		data := *templateStruct
+		data.Name = temp.Name
+		data.StructName = templateStructName(temp.Name, mainConfig.Impls)
+		data.CallerIdent = utils.GetFuncCallerIdent(data.StructName)
+		if err = renderTemplateFile(temp, data); err != nil {
+			return errors.WrapWithCode(err, errors.ErrCodeTemplate, fmt.Sprintf("生成模板 %s 失败: %s", temp.Name, err))
+		}
 	}
 	return
 }
 
+// servicePkgPath function    返回名为 service 的接口实现配置扫描目录的导入路径，未配置时返回空字符串.
+func servicePkgPath(impls []config.Impl) (string, error) {
+	for _, impl := range impls {
+		if impl.Name != "service" || len(impl.Scope) == 0 {
+			continue
+		}
+		modBase, err := utils.GetModBase()
+		if err != nil {
+			return "", errors.WrapWithCode(err, errors.ErrCodeConfig, fmt.Sprintf("获取模块路径失败: %s", err))
+		}
+		return path.Join(modBase, filepath.ToSlash(filepath.Clean(impl.Scope))), nil
+	}
+	return "", nil
+}
+
+// templateStructName function    返回模板生成的结构体名.
+// 如 dao_impl 模板使用名为 dao 的接口实现配置的 structName，未配置时使用模板名的驼峰形式.
+func templateStructName(name string, impls []config.Impl) string {
+	for _, impl := range impls {
+		if impl.Name == strings.TrimSuffix(name, "_impl") && len(impl.StructName) > 0 {
+			return impl.StructName
+		}
+	}
+	return strcase.UpperCamelCase(name)
+}
+
+// renderTemplateFile function    渲染模板及输出路径并写入文件.
+// 文件已存在且不覆盖时先将原文件写入 .bak 文件.
+func renderTemplateFile(temp TemplateFile, data any) (err error) {
+	pathTemplate, err := utils.NewTemplate("path").Parse(temp.Path)
+	if err != nil {
+		return errors.WrapWithCode(err, errors.ErrCodeTemplate, fmt.Sprintf("解析输出路径失败: %s", err))
+	}
+	var bf bytes.Buffer
+	if err = pathTemplate.Execute(&bf, data); err != nil {
+		return errors.WrapWithCode(err, errors.ErrCodeTemplate, fmt.Sprintf("渲染输出路径失败: %s", err))
+	}
+	fp := strings.TrimSpace(bf.String())
+	if len(fp) == 0 {
+		return errors.New(errors.ErrCodeConfig, "输出路径为空")
+	}
+	if err = utils.FixFilepathByProjectDir(&fp); err != nil {
+		return err
+	}
+
+	content, err := utils.ExecuteTemplate(temp.Content, data)
+	if err != nil {
+		return err
+	}
+
+	if err = backupFile(fp, temp.Overwrite); err != nil {
+		return err
+	}
+
+	logger.Info("generating template [ %s ] in [ %s ]", temp.Name, fp)
+	if strings.HasSuffix(fp, ".go") {
+		return utils.ImportAndWrite(content, fp)
+	}
+	if err = os.MkdirAll(filepath.Dir(fp), 0775); err != nil {
+		return errors.WrapWithCode(err, errors.ErrCodeFile, fmt.Sprintf("创建目录失败: %s", err))
+	}
+	if err = os.WriteFile(fp, content, 0664); err != nil {
+		return errors.WrapWithCode(err, errors.ErrCodeFile, fmt.Sprintf("写入文件失败: %s", err))
+	}
+	return nil
+}
+
+// backupFile function    文件已存在且不覆盖时将原文件写入 .bak 文件，之后由调用方写入新内容.
+func backupFile(path string, overwrite bool) error {
+	existing, err := os.ReadFile(path)
+	if err != nil || overwrite {
+		return nil
+	}
+	logger.Warn("file [ %s ] already exists, backup to %s", path, path+config.BackupSuffix)
+	if err = os.WriteFile(path+config.BackupSuffix, existing, 0664); err != nil {
+		return errors.WrapWithCode(err, errors.ErrCodeFile, fmt.Sprintf("备份文件失败: %s", err))
+	}
+	return nil
+}
+
 func parseTemplates(tableName string, tableBytes []byte, astFile *ast.File) (tmpl *Template, err error) {
 	object := astFile.Scope.Lookup(strcase.UpperCamelCase(tableName))
 	if object == nil {
@@ -84,23 +203,36 @@ func parseTemplates(tableName string, tableBytes []byte, astFile *ast.File) (tmp
 	}
 
 	for _, l := range structType.Fields.List {
-		var name string
+		var typeName string
 		switch typ := l.Type.(type) {
 		case *ast.Ident:
-			name = typ.Name
+			typeName = typ.Name
 		default:
-			name = string(tableBytes[typ.Pos()-1 : typ.End()-1])
+			typeName = string(tableBytes[typ.Pos()-1 : typ.End()-1])
 		}
 
-		field := Field{
-			Name: l.Names[0].Name,
-			Type: name,
-		}
+		var comment string
 		if l.Comment != nil {
-			field.Comment = strings.TrimSpace(strings.TrimPrefix(strings.TrimSpace(l.Comment.Text()), "//"))
+			comment = strings.TrimSpace(strings.TrimPrefix(strings.TrimSpace(l.Comment.Text()), "//"))
+		}
+		var tag string
+		if l.Tag != nil {
+			tag, _ = strconv.Unquote(l.Tag.Value)
+		}
+
+		// 匿名嵌入字段使用类型名作为字段名
+		names := make([]string, 0, len(l.Names))
+		for _, n := range l.Names {
+			names = append(names, n.Name)
+		}
+		if len(names) == 0 {
+			names = append(names, embeddedName(l.Type))
+		}
+		for _, name := range names {
+			tmpl.Fields = append(tmpl.Fields, newField(name, typeName, comment, tag))
 		}
-		tmpl.Fields = append(tmpl.Fields, field)
 	}
+	tmpl.PrimaryKey = primaryKey(tmpl.Fields)
 	return
 }
 
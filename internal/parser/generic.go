@@ -32,7 +32,7 @@ type MapType struct {
 }
 
 func init() {
-	defaultTemplate = template.Must(template.New("").Parse(template2.DefaultModelGenericTemplate))
+	defaultTemplate = template.Must(utils.NewTemplate("").Parse(template2.DefaultModelGenericTemplate))
 }
 
 // NewType function    创建一个类型映射文件.
@@ -79,6 +79,7 @@ type Api struct {
 	Title         string            // 标题
 	Options       map[string]string // 选项
 	AnnotationMap string            // 注释
+	Doc           []string          // 文档注释
 }
 
 // ParseApiFromService 从服务定义中解析出API组信息
@@ -214,6 +215,7 @@ func parseHttp(api ApiAnnotateItem, serviceName, groupRoute string) (ginApi *Api
 		Returns:    api.Returns,
 		Title:      api.Title,
 		Options:    api.Options,
+		Doc:        api.Doc,
 	}
 	return
 }
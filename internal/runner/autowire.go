@@ -0,0 +1,123 @@
+package runner
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/spelens-gud/gsus/internal/config"
+	"github.com/spelens-gud/gsus/internal/errors"
+	"github.com/spelens-gud/gsus/internal/generator"
+	"github.com/spelens-gud/gsus/internal/logger"
+	"github.com/spelens-gud/gsus/internal/template"
+	"github.com/spelens-gud/gsus/internal/utils"
+)
+
+// AutowireOptions struct    依赖注入生成选项.
+type AutowireOptions struct {
+	// 预留扩展字段
+}
+
+// Autowire function    执行依赖注入代码生成.
+// 扫描 Autowire.Scope 下带 @autowire 注解的结构体，并在 Autowire.Path 下生成构造函数及 Initialize 函数.
+func Autowire(ctx context.Context, opts *AutowireOptions, cfg config.Option) error {
+	log := logger.WithPrefix("[autowire]")
+	log.Info("开始执行 autowire 代码生成")
+
+	autowireConfig := cfg.Autowire
+	if len(autowireConfig.Scope) == 0 {
+		autowireConfig.Scope = "./"
+	}
+	if len(autowireConfig.Path) == 0 {
+		autowireConfig.Path = "./cmd/inject"
+	}
+	if len(autowireConfig.Template) == 0 {
+		autowireConfig.Template = "autowire"
+	}
+
+	// 修正路径
+	if err := utils.FixFilepathByProjectDir(&autowireConfig.Path); err != nil {
+		log.Error("无法解析注入代码目录")
+		return errors.WrapWithCode(err, errors.ErrCodeFile, fmt.Sprintf("无法解析注入代码目录: %s", err))
+	}
+
+	// 加载模板
+	autowireTemplate, _, err := template.Load(autowireConfig.Template)
+	if err != nil {
+		log.Error("加载依赖注入模板失败")
+		return errors.WrapWithCode(err, errors.ErrCodeTemplate, fmt.Sprintf("加载依赖注入模板失败: %s", err))
+	}
+
+	// 生成依赖注入代码
+	if err = generator.GenAutowire(generator.AutowireConfig{
+		Scope:    autowireConfig.Scope,
+		Path:     autowireConfig.Path,
+		Template: autowireTemplate,
+	}); err != nil {
+		log.Error("生成依赖注入代码失败")
+		return errors.WrapWithCode(err, errors.ErrCodeGenerate, fmt.Sprintf("生成依赖注入代码失败: %s", err))
+	}
+
+	log.Info("autowire 代码生成完成")
+	return nil
+}
+
+// AutowireGraphOptions struct    依赖关系图导出选项.
+type AutowireGraphOptions struct {
+	Format string // 导出格式（dot/mermaid）
+	Output string // 输出文件，为空时输出到标准输出
+}
+
+// AutowireGraph function    导出依赖关系图.
+// 扫描 Autowire.Scope 下带 @autowire 注解的结构体，按 set 分组导出 DOT 或 Mermaid 格式的依赖关系图.
+func AutowireGraph(ctx context.Context, opts *AutowireGraphOptions, cfg config.Option) error {
+	// 输出到标准输出时日志改为输出到标准错误，避免混入导出内容
+	if len(opts.Output) == 0 {
+		logger.SetOutput(os.Stderr)
+	}
+	log := logger.WithPrefix("[autowire]")
+	log.Info("开始导出依赖关系图")
+
+	scope := cfg.Autowire.Scope
+	if len(scope) == 0 {
+		scope = "./"
+	}
+	data, err := generator.GenAutowireGraph(generator.AutowireGraphConfig{
+		Scope:  scope,
+		Format: opts.Format,
+	})
+	if err != nil {
+		log.Error("导出依赖关系图失败")
+		return errors.WrapWithCode(err, errors.ErrCodeGenerate, fmt.Sprintf("导出依赖关系图失败: %s", err))
+	}
+
+	if len(opts.Output) == 0 {
+		_, _ = os.Stdout.Write(data)
+		return nil
+	}
+	output := opts.Output
+	if err = utils.FixFilepathByProjectDir(&output); err != nil {
+		log.Error("无法解析输出文件路径")
+		return errors.WrapWithCode(err, errors.ErrCodeFile, fmt.Sprintf("无法解析输出文件路径: %s", err))
+	}
+	if err = os.WriteFile(output, data, 0o644); err != nil {
+		log.Error("写入依赖关系图失败")
+		return errors.WrapWithCode(err, errors.ErrCodeFile, fmt.Sprintf("写入依赖关系图失败: %s", err))
+	}
+	log.Info("依赖关系图已导出: %s", output)
+	return nil
+}
+
+// RunAutoAutowire function    执行依赖注入代码生成（兼容旧接口）.
+func RunAutoAutowire(opts *AutowireOptions) {
+	config.ExecuteWithConfig(func(cfg config.Option) error {
+		return Autowire(context.Background(), opts, cfg)
+	})
+}
+
+// RunAutoAutowireGraph function    导出依赖关系图（兼容旧接口）.
+func RunAutoAutowireGraph(opts *AutowireGraphOptions) {
+	config.ExecuteWithConfig(func(cfg config.Option) error {
+		return AutowireGraph(context.Background(), opts, cfg)
+	})
+}
@@ -0,0 +1,136 @@
+package runner
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/spelens-gud/gsus/internal/config"
+	"github.com/spelens-gud/gsus/internal/errors"
+	"github.com/spelens-gud/gsus/internal/generator"
+	"github.com/spelens-gud/gsus/internal/logger"
+	"github.com/spelens-gud/gsus/internal/template"
+	"github.com/spelens-gud/gsus/internal/utils"
+)
+
+// templateCheck struct    待检查的配置模板.
+type templateCheck struct {
+	Key  string                 // 配置项路径
+	Name string                 // 模板名称
+	Kind generator.TemplateKind // 模板类型
+	Set  string                 // 接口实现集或 gsus template 模板名，用于生成示例数据
+	Path string                 // gsus template 输出路径模板
+	Data string                 // gsus template render 数据文件，设置时使用数据文件渲染
+}
+
+// TemplateCheck function    使用示例数据渲染所有已配置的模板，输出模板执行错误及无法解析或无法编译的生成代码.
+func TemplateCheck(ctx context.Context, opts *TemplateOptions, cfg config.Option) error {
+	log := logger.WithPrefix("[template]")
+	log.Info("开始检查模板")
+
+	checks := configuredTemplates(cfg)
+	var failed int
+	for _, c := range checks {
+		src, err := template.Find(c.Name)
+		if err != nil {
+			failed++
+			log.Error("%s: %s", c.Key, err)
+			continue
+		}
+		if err = checkTemplate(c); err != nil {
+			failed++
+			log.Error("%s: %s", c.Key, src.Locate(err))
+			continue
+		}
+		log.Debug("%s: %s 检查通过", c.Key, src.Path)
+	}
+
+	// 路径模板
+	for i, impl := range cfg.Impls {
+		if len(impl.Path) == 0 {
+			continue
+		}
+		if _, err := generator.RenderPath(generator.KindImplPath, impl.Name, impl.Path); err != nil {
+			failed++
+			log.Error("impls.%d.path: %s", i, err)
+		}
+	}
+	if len(cfg.Enum.Path) > 0 {
+		if _, err := generator.RenderPath(generator.KindEnum, "", cfg.Enum.Path); err != nil {
+			failed++
+			log.Error("enum.path: %s", err)
+		}
+	}
+
+	if failed > 0 {
+		return errors.New(errors.ErrCodeTemplate, fmt.Sprintf("模板检查未通过: %d 处错误", failed))
+	}
+	log.Info("已检查 %d 个模板，全部通过", len(checks))
+	return nil
+}
+
+// checkTemplate function    加载模板并使用示例数据渲染，数据模板使用配置的数据文件渲染.
+func checkTemplate(c templateCheck) error {
+	tmpl, _, err := template.Load(c.Name)
+	if err != nil {
+		return err
+	}
+	if c.Kind == generator.KindTemplate && len(c.Data) > 0 {
+		dataPath := c.Data
+		if err = utils.FixFilepathByProjectDir(&dataPath); err != nil {
+			return err
+		}
+		data, err := generator.LoadData(dataPath)
+		if err != nil {
+			return err
+		}
+		return generator.CheckDataTemplateFile(generator.TemplateFile{
+			Template: config.Template{Name: c.Set, Path: c.Path},
+			Content:  tmpl,
+		}, data)
+	}
+	if c.Kind == generator.KindTemplate {
+		return generator.CheckTemplateFile(generator.TemplateFile{
+			Template: config.Template{Name: c.Set, Path: c.Path},
+			Content:  tmpl,
+		})
+	}
+	_, err = generator.CheckTemplate(c.Kind, c.Set, tmpl)
+	return err
+}
+
+// configuredTemplates function    返回配置中使用的模板，未配置的模板使用生成器的默认模板.
+func configuredTemplates(cfg config.Option) (checks []templateCheck) {
+	add := func(key, name, def string, kind generator.TemplateKind, set string) {
+		if len(name) == 0 {
+			name = def
+		}
+		checks = append(checks, templateCheck{Key: key, Name: name, Kind: kind, Set: set})
+	}
+
+	for i, impl := range cfg.Impls {
+		add(fmt.Sprintf("impls.%d.template", i), impl.Template, "impl", generator.KindImpl, impl.Name)
+	}
+	add("http.router.template", cfg.Http.Router.Template, "http_router", generator.KindRouter, "")
+	add("http.client.apiTemplate", cfg.Http.Client.ApiTemplate, "http_client_api", generator.KindClientApi, "")
+	add("http.client.baseTemplate", cfg.Http.Client.BaseTemplate, "http_client_base", generator.KindClientBase, "")
+	add("enum.template", cfg.Enum.Template, "enum", generator.KindEnum, "")
+	add("autowire.template", cfg.Autowire.Template, "autowire", generator.KindAutowire, "")
+	add("db2struct.genericTemplate", cfg.Db2struct.GenericTemplate, "model_generic", generator.KindGeneric, "")
+
+	for i, t := range cfg.Templates.Templates {
+		key := fmt.Sprintf("templates.templates.%d.template", i)
+		name := t.Template
+		if len(name) == 0 {
+			key, name = fmt.Sprintf("templates.templates.%d.name", i), t.Name
+		}
+		checks = append(checks, templateCheck{Key: key, Name: name, Kind: generator.KindTemplate, Set: t.Name, Path: t.Path, Data: t.Data})
+	}
+	return checks
+}
+
+// RunAutoTemplateCheck function    执行模板检查操作.
+func RunAutoTemplateCheck(opts *TemplateOptions) {
+	config.ExecuteWithConfig(func(cfg config.Option) error {
+		return TemplateCheck(context.Background(), opts, cfg)
+	})
+}
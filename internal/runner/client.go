@@ -3,7 +3,6 @@ package runner
 import (
 	"context"
 	"fmt"
-	"path/filepath"
 
 	"github.com/spelens-gud/gsus/internal/config"
 	"github.com/spelens-gud/gsus/internal/errors"
@@ -12,33 +11,41 @@ import (
 	"github.com/spelens-gud/gsus/internal/parser"
 	"github.com/spelens-gud/gsus/internal/template"
 	"github.com/spelens-gud/gsus/internal/utils"
-	"github.com/spelens-gud/gsus/internal/validator"
 )
 
 // ClientOptions struct    HTTP 客户端生成选项.
 type ClientOptions struct {
-	ServicePath string // 服务路径
+	ServicePath string // 客户端代码输出目录，为空时使用配置
 }
 
 // Client function    执行 HTTP 客户端代码生成.
-func Client(ctx context.Context, opts *ClientOptions) error {
+// 扫描 Http.Scope 下的服务定义，使用 Http.Client 中的模板在 Http.Client.Path 下生成客户端代码.
+func Client(ctx context.Context, opts *ClientOptions, cfg config.Option) error {
 	log := logger.WithPrefix("[client]")
 	log.Info("开始执行 HTTP 客户端代码生成")
-	// 验证参数
-	if err := validator.ValidateRequired(opts.ServicePath, "service path"); err != nil {
-		log.Error("验证服务路径失败")
-		return errors.WrapWithCode(err, errors.ErrCodeParse, fmt.Sprintf("未能验证服务路径: %s", err))
+
+	clientConfig := cfg.Http.Client
+	if len(opts.ServicePath) > 0 {
+		clientConfig.Path = opts.ServicePath
+	}
+	if len(clientConfig.Path) == 0 {
+		clientConfig.Path = "clients"
+	}
+	if len(clientConfig.BaseTemplate) == 0 {
+		clientConfig.BaseTemplate = "http_client_base"
 	}
 
 	// 修正路径
-	clientPath := opts.ServicePath
+	clientPath := clientConfig.Path
 	if err := utils.FixFilepathByProjectDir(&clientPath); err != nil {
 		log.Error("无法解析客户端路径")
 		return errors.WrapWithCode(err, errors.ErrCodeFile, fmt.Sprintf("无法解析客户端路径: %s", err))
 	}
+	// 客户端目录下存在旧版 .gsus.client_api.tmpl 时继续使用
+	clientConfig.ApiTemplate = legacyTemplate(log, clientConfig.ApiTemplate, "http_client_api", clientPath, ".gsus.client_api")
 
 	// 搜索服务
-	svc, err := SearchServices("./")
+	svc, err := SearchServices(httpScope(cfg))
 	if err != nil {
 		log.Error("搜索服务失败")
 		return errors.WrapWithCode(err, errors.ErrCodeParse, fmt.Sprintf("搜索服务失败: %s", err))
@@ -52,17 +59,22 @@ func Client(ctx context.Context, opts *ClientOptions) error {
 	}
 
 	// 加载模板
-	templatePath := filepath.Join(clientPath, ".gsus.client_api"+config.GsusTemplateSuffix)
-	apiTemplate, _, err := template.InitAndLoad(templatePath, template.DefaultHttpClientApiTemplate)
+	apiTemplate, _, err := template.Load(clientConfig.ApiTemplate)
 	if err != nil {
 		log.Error("加载客户端API模板失败")
 		return errors.WrapWithCode(err, errors.ErrCodeTemplate, fmt.Sprintf("加载客户端API模板失败: %s", err))
 	}
+	baseTemplate, _, err := template.Load(clientConfig.BaseTemplate)
+	if err != nil {
+		log.Error("加载基础客户端模板失败")
+		return errors.WrapWithCode(err, errors.ErrCodeTemplate, fmt.Sprintf("加载基础客户端模板失败: %s", err))
+	}
 
 	// 生成客户端代码
 	if err := generator.GenClients(apiGroups, func(option *config.ClientOpt) {
 		option.ClientsPath = clientPath
 		option.ApiTemplate = apiTemplate
+		option.BaseTemplate = baseTemplate
 	}); err != nil {
 		log.Error("生成客户端代码失败")
 		return errors.WrapWithCode(err, errors.ErrCodeGenerate, fmt.Sprintf("生成客户端代码失败: %s", err))
@@ -74,7 +86,7 @@ func Client(ctx context.Context, opts *ClientOptions) error {
 
 // RunAutoClient function    执行 HTTP 客户端代码生成（兼容旧接口）.
 func RunAutoClient(opts *ClientOptions) {
-	config.ExecuteWithConfig(func(_ config.Option) error {
-		return Client(context.Background(), opts)
+	config.ExecuteWithConfig(func(cfg config.Option) error {
+		return Client(context.Background(), opts, cfg)
 	})
 }
@@ -2,38 +2,132 @@ package runner
 
 import (
 	"context"
+	"fmt"
+	"os"
+	"path/filepath"
 
 	"github.com/spelens-gud/gsus/internal/config"
+	"github.com/spelens-gud/gsus/internal/errors"
 	"github.com/spelens-gud/gsus/internal/logger"
+	"github.com/spelens-gud/gsus/internal/utils"
+	"gopkg.in/yaml.v3"
 )
 
-// ConfigOptions struct    配置选项.
+// ConfigOptions struct    配置管理选项.
 type ConfigOptions struct {
-	// 预留扩展字段
+	Key   string // 配置项路径，如 db2struct.path
+	Value string // 配置项的新值
+	Local bool   // 是否写入本地覆盖配置，默认为 .gsus/config.local.yaml
 }
 
-// Config function    执行配置管理.
-// 加载并处理项目配置，当前实现为占位符.
-func Config(ctx context.Context, opts *ConfigOptions) error {
-	log := logger.WithPrefix("[config]")
-	log.Info("开始执行 config 代码生成")
+// ConfigShow function    输出生效的配置.
+// 输出合并本地覆盖配置及 profile 后的配置，敏感配置项以 ****** 代替.
+func ConfigShow(ctx context.Context, opts *ConfigOptions, cfg config.Option) error {
+	cfg = cfg.Redact()
+	cfg.Profiles = nil
+
+	data, err := yaml.Marshal(&cfg)
+	if err != nil {
+		return errors.WrapWithCode(err, errors.ErrCodeConfig, fmt.Sprintf("序列化 gsus 配置错误: %s", err))
+	}
+	if profile := config.Profile(); len(profile) > 0 {
+		data = append([]byte(fmt.Sprintf("# profile: %s\n", profile)), data...)
+	}
+	_, _ = os.Stdout.Write(data)
+	return nil
+}
 
-	cfg, err := config.Get()
+// ConfigGet function    输出生效配置中的单个配置项.
+// 单个值直接输出，映射及列表以 yaml 格式输出.
+func ConfigGet(ctx context.Context, opts *ConfigOptions, cfg config.Option) error {
+	node, err := cfg.Lookup(opts.Key)
 	if err != nil {
 		return err
 	}
+	if node.Kind == yaml.ScalarNode {
+		fmt.Println(node.Value)
+		return nil
+	}
+	data, err := yaml.Marshal(node)
+	if err != nil {
+		return errors.WrapWithCode(err, errors.ErrCodeConfig, fmt.Sprintf("序列化配置项失败: %s", err))
+	}
+	_, _ = os.Stdout.Write(data)
+	return nil
+}
+
+// ConfigSet function    修改配置文件中的单个配置项.
+// 直接编辑配置文件，保留注释及其他配置项，修改后重新校验配置并输出警告.
+func ConfigSet(ctx context.Context, opts *ConfigOptions) error {
+	log := logger.WithPrefix("[config]")
+
+	path := config.ConfigFile()
+	if opts.Local {
+		path = config.LocalConfigFile()
+	}
+	if err := utils.FixFilepathByProjectDir(&path); err != nil {
+		return errors.WrapWithCode(err, errors.ErrCodeFile, fmt.Sprintf("无法解析配置文件路径: %s", err))
+	}
+
+	content, err := os.ReadFile(path)
+	if err != nil && (!opts.Local || !os.IsNotExist(err)) {
+		return errors.WrapWithCode(err, errors.ErrCodeFile, fmt.Sprintf("读取配置文件失败: %s", path))
+	}
+	if content, err = config.SetValue(content, opts.Key, opts.Value); err != nil {
+		return err
+	}
+	if err = os.MkdirAll(filepath.Dir(path), 0o775); err != nil {
+		return errors.WrapWithCode(err, errors.ErrCodeFile, fmt.Sprintf("创建配置目录失败: %s", err))
+	}
+	if err = os.WriteFile(path, content, 0o664); err != nil {
+		return errors.WrapWithCode(err, errors.ErrCodeFile, fmt.Sprintf("写入配置文件失败: %s", err))
+	}
+	log.Info("已设置 %s: %s", opts.Key, path)
 
-	// 配置相关的业务逻辑
-	// 目前原实现为空，保留接口
-	_ = cfg
+	if _, err = config.Get(); err != nil {
+		log.Warn("配置已修改，但校验未通过: %v", err)
+	}
+	return nil
+}
 
-	log.Info("config 模版生成成功")
+// ConfigSchema function    输出配置文件的 JSON Schema.
+func ConfigSchema(ctx context.Context, opts *ConfigOptions) error {
+	data, err := config.Schema()
+	if err != nil {
+		return err
+	}
+	_, _ = os.Stdout.Write(data)
 	return nil
 }
 
-// RunAutoConfig function    执行配置操作.
-func RunAutoConfig(opts *ConfigOptions) {
-	config.ExecuteWithConfig(func(_ config.Option) (err error) {
-		return Config(context.Background(), opts)
+// RunAutoConfigShow function    输出生效的配置.
+func RunAutoConfigShow(opts *ConfigOptions) {
+	// 日志输出到标准错误，避免混入配置内容
+	logger.SetOutput(os.Stderr)
+	config.ExecuteWithConfig(func(cfg config.Option) error {
+		return ConfigShow(context.Background(), opts, cfg)
+	})
+}
+
+// RunAutoConfigGet function    输出生效配置中的单个配置项.
+func RunAutoConfigGet(opts *ConfigOptions) {
+	logger.SetOutput(os.Stderr)
+	config.ExecuteWithConfig(func(cfg config.Option) error {
+		return ConfigGet(context.Background(), opts, cfg)
+	})
+}
+
+// RunAutoConfigSet function    修改配置文件中的单个配置项.
+// 不要求现有配置通过校验，便于修正错误的配置项.
+func RunAutoConfigSet(opts *ConfigOptions) {
+	utils.Execute(func() error {
+		return ConfigSet(context.Background(), opts)
+	})
+}
+
+// RunAutoConfigSchema function    输出配置文件的 JSON Schema.
+func RunAutoConfigSchema(opts *ConfigOptions) {
+	utils.Execute(func() error {
+		return ConfigSchema(context.Background(), opts)
 	})
 }
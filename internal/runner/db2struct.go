@@ -12,6 +12,7 @@ import (
 	"github.com/spelens-gud/gsus/internal/logger"
 	"github.com/spelens-gud/gsus/internal/template"
 	"github.com/spelens-gud/gsus/internal/utils"
+	"github.com/spelens-gud/gsus/internal/validator"
 	"github.com/stoewer/go-strcase"
 )
 
@@ -48,6 +49,9 @@ func Db2struct(ctx context.Context, opts *Db2structOptions, cfg config.Option) e
 		config.WithCommentOutside(),
 		config.WithGormAnnotation(),
 	)
+	if db2structConfig.EnumType {
+		genOpts = append(genOpts, config.WithEnumType())
+	}
 
 	// 修正路径
 	if err := utils.FixFilepathByProjectDir(&db2structConfig.Path); err != nil {
@@ -161,9 +165,34 @@ func generateModelsForTablesV2(ctx context.Context, tables []string, outputPath
 }
 
 // validateDBConfig function    验证数据库配置.
+// 检查数据库类型是否已注册，并按类型检查连接参数.
 func validateDBConfig(cfg config.Db2struct) error {
-	// 导入 validator 包
-	return nil // 这里可以添加具体的验证逻辑
+	dbType := db.Type(cfg.Type)
+	if dbType == "" {
+		dbType = db.MySQL
+	}
+	if _, err := db.GetAdapter(dbType); err != nil {
+		return err
+	}
+
+	switch dbType {
+	case db.SQLite:
+		// SQLite 使用 db 或 host 作为数据库文件路径
+		if len(cfg.Db) == 0 {
+			return validator.ValidateRequired(cfg.Host, "db2struct.db")
+		}
+		return nil
+	case db.MongoDB:
+		if err := validator.ValidateRequired(cfg.Host, "db2struct.host"); err != nil {
+			return err
+		}
+		if err := validator.ValidateRequired(cfg.Db, "db2struct.db"); err != nil {
+			return err
+		}
+		return validator.ValidatePort(cfg.Port)
+	default:
+		return validator.ValidateDBConfig(cfg.User, cfg.Password, cfg.Host, cfg.Db, cfg.Port)
+	}
 }
 
 // RunAutoDb2Struct function    执行数据库表转结构体（兼容旧接口）.
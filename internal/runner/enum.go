@@ -2,9 +2,13 @@ package runner
 
 import (
 	"context"
+	"fmt"
 
 	"github.com/spelens-gud/gsus/internal/config"
+	"github.com/spelens-gud/gsus/internal/errors"
+	"github.com/spelens-gud/gsus/internal/generator"
 	"github.com/spelens-gud/gsus/internal/logger"
+	"github.com/spelens-gud/gsus/internal/template"
 )
 
 // EnumOptions struct    枚举生成选项.
@@ -13,12 +17,35 @@ type EnumOptions struct {
 }
 
 // Enum function    执行枚举生成.
+// 扫描 Enum.Scope 下带 @enum 注解的常量块，并按 Enum.Template 生成枚举方法.
 func Enum(ctx context.Context, opts *EnumOptions, cfg config.Option) error {
 	log := logger.WithPrefix("[enum]")
 	log.Info("开始执行 enum 代码生成")
 
-	// 配置相关的业务逻辑
-	_ = cfg
+	enumConfig := cfg.Enum
+	if len(enumConfig.Scope) == 0 {
+		enumConfig.Scope = "./"
+	}
+	if len(enumConfig.Template) == 0 {
+		enumConfig.Template = "enum"
+	}
+
+	// 加载模板
+	enumTemplate, _, err := template.Load(enumConfig.Template)
+	if err != nil {
+		log.Error("加载枚举模板失败")
+		return errors.WrapWithCode(err, errors.ErrCodeTemplate, fmt.Sprintf("加载枚举模板失败: %s", err))
+	}
+
+	// 生成枚举代码
+	if err = generator.GenEnums(generator.EnumConfig{
+		Scope:    enumConfig.Scope,
+		Path:     enumConfig.Path,
+		Template: enumTemplate,
+	}); err != nil {
+		log.Error("生成枚举代码失败")
+		return errors.WrapWithCode(err, errors.ErrCodeGenerate, fmt.Sprintf("生成枚举代码失败: %s", err))
+	}
 
 	log.Info("enum 代码生成完成")
 	return nil
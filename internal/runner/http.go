@@ -1,7 +1,10 @@
 package runner
 
 import (
+	"context"
 	"fmt"
+	"os"
+	"path/filepath"
 	"sync"
 
 	"github.com/spelens-gud/gsus/internal/config"
@@ -33,3 +36,58 @@ func SearchServices(scope string) (services []parser.Service, err error) {
 	})
 	return
 }
+
+// HttpOptions struct    HTTP 代码生成选项.
+type HttpOptions struct {
+	// 预留扩展字段
+}
+
+// Http function    执行全部 HTTP 代码生成.
+// 按配置依次生成路由、客户端代码及接口文档.
+func Http(ctx context.Context, opts *HttpOptions, cfg config.Option) error {
+	log := logger.WithPrefix("[http]")
+	log.Info("开始执行 http 代码生成")
+
+	if err := Router(ctx, &RouterOptions{}, cfg); err != nil {
+		return err
+	}
+	if err := Client(ctx, &ClientOptions{}, cfg); err != nil {
+		return err
+	}
+	if err := Swagger(ctx, &SwaggerOptions{}, cfg); err != nil {
+		return err
+	}
+
+	log.Info("http 代码生成完成")
+	return nil
+}
+
+// RunAutoHttp function    执行全部 HTTP 代码生成（兼容旧接口）.
+func RunAutoHttp(opts *HttpOptions) {
+	config.ExecuteWithConfig(func(cfg config.Option) error {
+		return Http(context.Background(), opts, cfg)
+	})
+}
+
+// httpScope function    返回服务定义扫描范围，未配置时扫描整个项目.
+func httpScope(cfg config.Option) string {
+	if len(cfg.Http.Scope) == 0 {
+		return "./"
+	}
+	return cfg.Http.Scope
+}
+
+// legacyTemplate function    返回生成目录下旧版自定义模板的路径，未配置模板或配置为默认模板且旧版模板存在时使用并提示迁移.
+// 其他情况返回配置的模板名称，未配置时返回默认模板名称.
+func legacyTemplate(log logger.Logger, configured, def, dir, legacyName string) string {
+	if len(configured) > 0 && configured != def {
+		return configured
+	}
+	path := filepath.Join(dir, legacyName+config.GsusTemplateSuffix)
+	if _, err := os.Stat(path); err != nil {
+		return def
+	}
+	log.Warn("使用旧版自定义模板 %s，该位置已废弃，请执行 gsus template eject %s 并将修改移至 %s 后删除旧模板",
+		path, def, filepath.Join(config.GsusTemplateDir, def+config.GsusTemplateSuffix))
+	return path
+}
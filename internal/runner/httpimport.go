@@ -0,0 +1,59 @@
+package runner
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/spelens-gud/gsus/internal/config"
+	"github.com/spelens-gud/gsus/internal/errors"
+	"github.com/spelens-gud/gsus/internal/generator"
+	"github.com/spelens-gud/gsus/internal/logger"
+	"github.com/spelens-gud/gsus/internal/utils"
+)
+
+// HttpImportOptions struct    OpenAPI 文档导入选项.
+type HttpImportOptions struct {
+	Spec      string // OpenAPI/Swagger 文档路径
+	Pkg       string // 输出包目录，默认使用 http.scope
+	Overwrite bool   // 是否覆盖已存在的文件
+}
+
+// HttpImport function    根据 OpenAPI 文档生成 @service 接口定义.
+func HttpImport(ctx context.Context, opts *HttpImportOptions, cfg config.Option) error {
+	log := logger.WithPrefix("[import]")
+	log.Info("开始执行 OpenAPI 文档导入")
+
+	dir := opts.Pkg
+	if len(dir) == 0 {
+		dir = cfg.Http.Scope
+	}
+	if len(dir) == 0 {
+		dir = "service"
+		log.Debug("使用默认输出目录: %s", dir)
+	}
+
+	// 修正路径
+	if err := utils.FixFilepathByProjectDir(&dir); err != nil {
+		log.Error("无法解析输出目录")
+		return errors.WrapWithCode(err, errors.ErrCodeFile, fmt.Sprintf("无法解析输出目录: %s", err))
+	}
+
+	if err := generator.ImportOpenAPI(generator.ImportOpenAPIConfig{
+		Spec:      opts.Spec,
+		Dir:       dir,
+		Overwrite: opts.Overwrite,
+	}); err != nil {
+		log.Error("导入 OpenAPI 文档失败")
+		return errors.WrapWithCode(err, errors.ErrCodeGenerate, fmt.Sprintf("导入 OpenAPI 文档失败: %s", err))
+	}
+
+	log.Info("导入 OpenAPI 文档成功: %s", dir)
+	return nil
+}
+
+// RunAutoHttpImport function    执行 OpenAPI 文档导入（兼容旧接口）.
+func RunAutoHttpImport(opts *HttpImportOptions) {
+	config.ExecuteWithConfig(func(cfg config.Option) error {
+		return HttpImport(context.Background(), opts, cfg)
+	})
+}
@@ -3,6 +3,7 @@ package runner
 import (
 	"context"
 	"fmt"
+	"os"
 	"path/filepath"
 
 	"github.com/spelens-gud/gsus/internal/config"
@@ -19,13 +20,19 @@ type ImplOptions struct {
 	Interface string // 接口名称
 	Struct    string // 实现目录
 	Prefix    string // 文件目录前缀
+	Name      string // 仅同步指定名称的 impls 配置
 }
 
 // Impl function    执行接口实现代码生成.
-func Impl(ctx context.Context, opts *ImplOptions) error {
+// 未指定接口及实现目录时，按配置中的 impls 列表同步所有实现集.
+func Impl(ctx context.Context, opts *ImplOptions, cfg config.Option) error {
 	log := logger.WithPrefix("[impl]")
 	log.Info("开始执行 impl 代码生成")
 
+	if len(opts.Interface) == 0 && len(opts.Struct) == 0 {
+		return implByConfig(log, opts, cfg.Impls)
+	}
+
 	// 验证参数
 	if err := validator.ValidateRequired(opts.Interface, "interface name"); err != nil {
 		log.Error("验证接口名称失败")
@@ -50,9 +57,8 @@ func Impl(ctx context.Context, opts *ImplOptions) error {
 		return errors.WrapWithCode(err, errors.ErrCodeFile, fmt.Sprintf("无法解析实现目录: %s", err))
 	}
 
-	// 加载模板
-	templatePath := filepath.Join(opts.Struct, ".gsus.impl"+config.GsusTemplateSuffix)
-	temp, _, err := template.InitAndLoad(templatePath, template.DefaultImplTemplate)
+	// 加载模板，结构体目录下存在旧版 .gsus.impl.tmpl 时继续使用
+	temp, _, err := template.Load(legacyTemplate(log, "", "impl", opts.Struct, ".gsus.impl"))
 	if err != nil {
 		log.Error("加载实现模板失败")
 		return errors.WrapWithCode(err, errors.ErrCodeTemplate, fmt.Sprintf("加载实现模板失败: %s", err))
@@ -69,9 +75,86 @@ func Impl(ctx context.Context, opts *ImplOptions) error {
 	return nil
 }
 
+// implByConfig function    按 impls 配置同步接口实现.
+func implByConfig(log logger.Logger, opts *ImplOptions, impls []config.Impl) error {
+	var matched int
+	for _, implConfig := range impls {
+		if len(opts.Name) > 0 && implConfig.Name != opts.Name {
+			continue
+		}
+		matched++
+		if err := validator.ValidateRequired(implConfig.Name, "impls name"); err != nil {
+			log.Error("验证实现集名称失败")
+			return errors.WrapWithCode(err, errors.ErrCodeConfig, fmt.Sprintf("验证实现集名称失败: %s", err))
+		}
+		if len(implConfig.Scope) == 0 {
+			implConfig.Scope = "./"
+		}
+		if len(implConfig.Template) == 0 {
+			implConfig.Template = "impl"
+		}
+
+		// 构建生成配置
+		syncConfig := &generator.Config{
+			SetName:    implConfig.Name,
+			Scope:      implConfig.Scope,
+			Prefix:     opts.Prefix,
+			Path:       implConfig.Path,
+			StructName: implConfig.StructName,
+		}
+
+		// 修正路径
+		if err := utils.FixFilepathByProjectDir(&syncConfig.Scope); err != nil {
+			log.Error("无法解析接口扫描范围")
+			return errors.WrapWithCode(err, errors.ErrCodeFile, fmt.Sprintf("无法解析接口扫描范围: %s", err))
+		}
+		if _, err := os.Stat(syncConfig.Scope); os.IsNotExist(err) {
+			log.Warn("实现集 %s 的扫描范围 %s 不存在，已跳过", implConfig.Name, syncConfig.Scope)
+			continue
+		}
+		if len(syncConfig.Path) > 0 {
+			if err := utils.FixFilepathByProjectDir(&syncConfig.Path); err != nil {
+				log.Error("无法解析实现文件路径")
+				return errors.WrapWithCode(err, errors.ErrCodeFile, fmt.Sprintf("无法解析实现文件路径: %s", err))
+			}
+		} else {
+			syncConfig.ImplementsDir = filepath.Join("./", "internal", implConfig.Name+"_impls")
+			if err := utils.FixFilepathByProjectDir(&syncConfig.ImplementsDir); err != nil {
+				log.Error("无法解析实现目录")
+				return errors.WrapWithCode(err, errors.ErrCodeFile, fmt.Sprintf("无法解析实现目录: %s", err))
+			}
+		}
+
+		// 加载模板
+		temp, _, err := template.Load(implConfig.Template)
+		if err != nil {
+			log.Error("加载实现模板失败")
+			return errors.WrapWithCode(err, errors.ErrCodeTemplate, fmt.Sprintf("加载实现模板失败: %s", err))
+		}
+		syncConfig.ImplBaseTemplate = temp
+
+		// 同步接口实现
+		log.Info("同步实现集: %s", implConfig.Name)
+		if err = syncConfig.SyncInterfaceImpls(); err != nil {
+			log.Error("同步接口实现失败")
+			return errors.WrapWithCode(err, errors.ErrCodeGenerate, fmt.Sprintf("同步实现集 %s 失败: %s", implConfig.Name, err))
+		}
+	}
+
+	if matched == 0 {
+		if len(opts.Name) > 0 {
+			return errors.New(errors.ErrCodeConfig, fmt.Sprintf("未找到名为 %s 的 impls 配置", opts.Name))
+		}
+		return errors.New(errors.ErrCodeConfig, "未配置 impls，请在配置文件中添加 impls 或指定接口名和实现目录")
+	}
+
+	log.Info("接口实现代码生成完成")
+	return nil
+}
+
 // RunAutoImpl function    执行接口实现代码生成（兼容旧接口）.
 func RunAutoImpl(opts *ImplOptions) {
-	config.ExecuteWithConfig(func(_ config.Option) error {
-		return Impl(context.Background(), opts)
+	config.ExecuteWithConfig(func(cfg config.Option) error {
+		return Impl(context.Background(), opts, cfg)
 	})
 }
@@ -55,45 +55,39 @@ func RunAutoInit(opts *InitOptions) {
 			return errors.WrapWithCode(err, errors.ErrCodeConfig, fmt.Sprintf("写入默认配置文件失败: %s", err))
 		}
 
-		// 写入默认配置文件
-		if err = os.WriteFile(filepath.Join(dir, config.GsusConfigFile), bytes, 0664); err != nil {
+		// 写入默认配置文件，--config 指定的目录不存在时一并创建
+		configFile := config.ConfigFile()
+		if err = utils.FixFilepathByProjectDir(&configFile); err != nil {
+			return err
+		}
+		if err = os.MkdirAll(filepath.Dir(configFile), 0775); err != nil {
+			log.Error("创建配置目录失败")
+			return errors.WrapWithCode(err, errors.ErrCodeConfig, fmt.Sprintf("创建配置目录失败: %s", err))
+		}
+		if err = os.WriteFile(configFile, bytes, 0664); err != nil {
 			log.Error("写入默认配置文件失败")
 			return errors.WrapWithCode(err, errors.ErrCodeConfig, fmt.Sprintf("写入默认配置文件失败: %s", err))
 		}
 
+		// 忽略本地覆盖配置，避免提交数据库密码等个人配置
+		if config.ConfigFile() == config.GsusConfigFile {
+			gitignore := filepath.Base(config.GsusLocalConfigFile) + "\n"
+			if err = os.WriteFile(filepath.Join(dir, config.GsusConfigDir, ".gitignore"), []byte(gitignore), 0664); err != nil {
+				log.Error("写入 .gitignore 失败")
+				return errors.WrapWithCode(err, errors.ErrCodeFile, fmt.Sprintf("写入 .gitignore 失败: %s", err))
+			}
+		} else {
+			log.Warn("请将本地覆盖配置 %s 加入 .gitignore", config.LocalConfigFile())
+		}
+
 		// 创建模板目录
 		if err = os.Mkdir(filepath.Join(dir, config.GsusTemplateDir), 0775); err != nil {
 			log.Error("创建模板目录失败")
 			return errors.WrapWithCode(err, errors.ErrCodeConfig, fmt.Sprintf("创建模板目录失败: %s", err))
 		}
 
-		log.Info("开始写入模板文件")
-		// 写入模板文件
-		return writeTemplates(dir, getTemplateMap())
+		// 模板默认使用内置版本，需要定制时再导出到项目中
+		log.Info("模板默认使用内置版本，执行 gsus template eject <name> 导出到 %s 后可自定义", config.GsusTemplateDir)
+		return nil
 	})
 }
-
-func writeTemplates(dir string, contentMap map[string]string) error {
-	for path, content := range contentMap {
-		p := filepath.Join(dir, config.GsusTemplateDir, path+config.GsusTemplateSuffix)
-		if err := os.WriteFile(p, []byte(content), 0664); err != nil {
-			return errors.WrapWithCode(err, errors.ErrCodeTemplate, fmt.Sprintf("写入模板文件失败: %s", err))
-		}
-	}
-	return nil
-}
-
-func getTemplateMap() map[string]string {
-	return map[string]string{
-		"impl":             template.DefaultImplTemplate,
-		"http_router":      template.DefaultHttpRouterTemplate,
-		"http_client_api":  template.DefaultHttpClientApiTemplate,
-		"http_client_base": template.DefaultHttpClientBaseTemplate,
-		"dao":              template.DefaultDaoTemplate,
-		"dao_impl":         template.DefaultDaoImplTemplate,
-		"service":          template.DefaultServiceTemplate,
-		"service_impl":     template.DefaultServiceImplTemplate,
-		"model_cast":       template.DefaultModelCastTemplate,
-		"model_generic":    template.DefaultModelGenericTemplate,
-	}
-}
@@ -3,22 +3,25 @@ package runner
 import (
 	"context"
 	"fmt"
+	"sort"
+	"strings"
 
 	"github.com/spelens-gud/gsus/internal/config"
 	"github.com/spelens-gud/gsus/internal/errors"
 	"github.com/spelens-gud/gsus/internal/generator"
 	"github.com/spelens-gud/gsus/internal/logger"
+	"github.com/spelens-gud/gsus/internal/validator"
 )
 
 // MountOptions struct    挂载选项.
 // 包含挂载操作所需的参数列表.
 type MountOptions struct {
-	Args []string // 挂载参数列表
+	Args []string // 挂载参数列表，配置了 mounts 时作为挂载名称过滤
 }
 
 // Mount function    执行挂载操作.
-// 根据提供的参数执行代码挂载生成.
-func Mount(ctx context.Context, opts *MountOptions) error {
+// 配置了 mounts 时依次处理每个挂载目标，否则根据 @mount 注解执行代码挂载生成.
+func Mount(ctx context.Context, opts *MountOptions, cfg config.Option) error {
 	log := logger.WithPrefix("[mount]")
 	log.Info("开始执行 mount 代码生成")
 
@@ -26,6 +29,46 @@ func Mount(ctx context.Context, opts *MountOptions) error {
 	for _, arg := range opts.Args {
 		argsMap[arg] = true
 	}
+
+	if len(cfg.Mounts) > 0 {
+		matched := make(map[string]bool, len(argsMap))
+		for _, mount := range cfg.Mounts {
+			if len(argsMap) > 0 && !argsMap[mount.Name] {
+				continue
+			}
+			matched[mount.Name] = true
+			if err := validator.ValidateRequired(mount.Name, "mounts name"); err != nil {
+				log.Error("验证挂载名称失败")
+				return errors.WrapWithCode(err, errors.ErrCodeConfig, fmt.Sprintf("验证挂载名称失败: %s", err))
+			}
+			if err := validator.ValidateRequired(mount.Path, "mounts path"); err != nil {
+				log.Error("验证挂载目标文件失败")
+				return errors.WrapWithCode(err, errors.ErrCodeConfig, fmt.Sprintf("验证挂载 %s 的目标文件失败: %s", mount.Name, err))
+			}
+			if err := validator.ValidateRequired(mount.Struct, "mounts struct"); err != nil {
+				log.Error("验证挂载目标结构体失败")
+				return errors.WrapWithCode(err, errors.ErrCodeConfig, fmt.Sprintf("验证挂载 %s 的目标结构体失败: %s", mount.Name, err))
+			}
+			log.Info("挂载 @%s 到 %s:%s", mount.Name, mount.Path, mount.Struct)
+			if err := generator.Exec(mount); err != nil {
+				log.Error("mount 生成错误")
+				return errors.WrapWithCode(err, errors.ErrCodeGenerate, fmt.Sprintf("mount %s 生成错误: %v", mount.Name, err))
+			}
+		}
+		var names []string
+		for name := range argsMap {
+			if !matched[name] {
+				names = append(names, name)
+			}
+		}
+		if len(names) > 0 || len(matched) == 0 {
+			sort.Strings(names)
+			return errors.New(errors.ErrCodeConfig, fmt.Sprintf("未找到挂载配置: %s", strings.Join(names, ",")))
+		}
+		log.Info("mount 模板生成成功")
+		return nil
+	}
+
 	if err := generator.Exec(config.Mount{
 		Args: opts.Args,
 	}); err != nil {
@@ -40,7 +83,7 @@ func Mount(ctx context.Context, opts *MountOptions) error {
 // RunAutoMount function    执行挂载操作（兼容旧接口）.
 // 自动加载配置并执行挂载逻辑.
 func RunAutoMount(opts *MountOptions) {
-	config.ExecuteWithConfig(func(_ config.Option) (err error) {
-		return Mount(context.Background(), opts)
+	config.ExecuteWithConfig(func(cfg config.Option) (err error) {
+		return Mount(context.Background(), opts, cfg)
 	})
 }
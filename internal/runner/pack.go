@@ -0,0 +1,93 @@
+package runner
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/spelens-gud/gsus/internal/config"
+	"github.com/spelens-gud/gsus/internal/errors"
+	"github.com/spelens-gud/gsus/internal/logger"
+	"github.com/spelens-gud/gsus/internal/template"
+	"github.com/spelens-gud/gsus/internal/utils"
+)
+
+// PackOptions struct    模板包操作选项.
+type PackOptions struct {
+	Sources []string // 模板包目录或 .tar.gz 归档路径
+}
+
+// TemplatePackInstall function    从本地目录或归档安装模板包到 .gsus/packs 下.
+// 安装后检查模板包所需的配置项，缺失时输出警告.
+func TemplatePackInstall(ctx context.Context, opts *PackOptions) error {
+	log := logger.WithPrefix("[pack]")
+	if len(opts.Sources) == 0 {
+		return errors.New(errors.ErrCodeTemplate, "请指定模板包目录或 .tar.gz 归档")
+	}
+
+	var keys []string
+	for _, src := range opts.Sources {
+		m, previous, err := template.InstallPack(src)
+		if err != nil {
+			log.Error("安装模板包 %s 失败", src)
+			return err
+		}
+		switch previous {
+		case "":
+			log.Info("已安装模板包 %s@%s，模板: %s", m.Name, m.Version, strings.Join(m.Templates, ", "))
+		case m.Version:
+			log.Info("已重新安装模板包 %s@%s", m.Name, m.Version)
+		default:
+			log.Info("已更新模板包 %s: %s → %s", m.Name, previous, m.Version)
+		}
+		log.Info("在配置中通过 %s:<模板> 引用，如 %s:%s", m.Name, m.Name, m.Templates[0])
+		keys = append(keys, m.Config...)
+	}
+	if len(keys) == 0 {
+		return nil
+	}
+
+	// 检查模板包所需的配置项
+	cfg, err := config.Get()
+	if err != nil {
+		log.Warn("无法加载项目配置，跳过模板包所需配置项检查: %s", err)
+		return nil
+	}
+	if missing := cfg.MissingKeys(keys); len(missing) > 0 {
+		log.Warn("模板包需要设置以下配置项，可执行 gsus config set 设置: %s", strings.Join(missing, ", "))
+	}
+	return nil
+}
+
+// RunAutoTemplatePackInstall function    执行模板包安装操作.
+func RunAutoTemplatePackInstall(opts *PackOptions) {
+	utils.Execute(func() error {
+		return TemplatePackInstall(context.Background(), opts)
+	})
+}
+
+// TemplatePackList function    输出已安装的模板包.
+func TemplatePackList(ctx context.Context, opts *PackOptions) error {
+	packs, err := template.ListPacks()
+	if err != nil {
+		return err
+	}
+	w := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+	_, _ = fmt.Fprintln(w, "名称\t版本\t模板\t说明")
+	for _, m := range packs {
+		_, _ = fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", m.Name, m.Version, strings.Join(m.Templates, ","), m.Description)
+	}
+	if err = w.Flush(); err != nil {
+		return errors.WrapWithCode(err, errors.ErrCodeFile, fmt.Sprintf("输出模板包列表失败: %s", err))
+	}
+	return nil
+}
+
+// RunAutoTemplatePackList function    输出已安装的模板包.
+func RunAutoTemplatePackList(opts *PackOptions) {
+	utils.Execute(func() error {
+		return TemplatePackList(context.Background(), opts)
+	})
+}
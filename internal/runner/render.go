@@ -0,0 +1,120 @@
+package runner
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/spelens-gud/gsus/internal/config"
+	"github.com/spelens-gud/gsus/internal/errors"
+	"github.com/spelens-gud/gsus/internal/generator"
+	"github.com/spelens-gud/gsus/internal/logger"
+	"github.com/spelens-gud/gsus/internal/template"
+	"github.com/spelens-gud/gsus/internal/utils"
+)
+
+// TemplateRender function    使用 YAML/JSON 数据文件渲染模板.
+// 未指定模板时渲染 templates.templates 中所有设置了 data 的模板，
+// 指定的模板名与配置项同名时使用其配置，命令行参数优先；未设置输出路径时输出到标准输出.
+func TemplateRender(ctx context.Context, opts *TemplateOptions, cfg config.Option) error {
+	log := logger.WithPrefix("[template]")
+
+	targets, err := renderTargets(opts, cfg)
+	if err != nil {
+		return err
+	}
+	if len(targets) == 0 {
+		log.Warn("未配置数据模板，请传入模板名称及 --data，或在 templates.templates 中设置 data")
+		return nil
+	}
+
+	manager := template.NewManager()
+	for _, t := range targets {
+		name := t.Template
+		if len(name) == 0 {
+			name = t.Name
+		}
+		if err = manager.LoadByName(t.Name, name); err != nil {
+			log.Error("加载模板 %s 失败", name)
+			return errors.WrapWithCode(err, errors.ErrCodeTemplate, fmt.Sprintf("加载模板 %s 失败: %s", name, err))
+		}
+		data, err := generator.LoadData(t.Data)
+		if err != nil {
+			log.Error("读取数据文件失败")
+			return err
+		}
+
+		if len(t.Path) == 0 {
+			content, err := manager.Render(t.Name, data)
+			if err != nil {
+				return errors.WrapWithCode(err, errors.ErrCodeTemplate, fmt.Sprintf("渲染模板 %s 失败: %s", name, err))
+			}
+			_, _ = fmt.Fprint(os.Stdout, content)
+			continue
+		}
+		tmpl, _ := manager.Get(t.Name)
+		if err = generator.GenDataTemplate(generator.TemplateFile{Template: t, Content: tmpl}, data); err != nil {
+			log.Error("生成模板代码失败")
+			return err
+		}
+	}
+
+	log.Info("生成模板代码成功")
+	return nil
+}
+
+// renderTargets function    返回待渲染的数据模板，配置中的数据文件路径相对项目目录，命令行传入的路径相对当前目录.
+func renderTargets(opts *TemplateOptions, cfg config.Option) ([]config.Template, error) {
+	var targets []config.Template
+	if len(opts.Names) == 0 {
+		for _, t := range cfg.Templates.Templates {
+			if len(t.Data) > 0 {
+				targets = append(targets, t)
+			}
+		}
+	} else {
+		target := config.Template{Name: opts.Names[0]}
+		for _, t := range cfg.Templates.Templates {
+			if t.Name == target.Name && len(t.Data) > 0 {
+				target = t
+				break
+			}
+		}
+		if len(opts.Path) > 0 {
+			target.Path = opts.Path
+		}
+		targets = append(targets, target)
+	}
+
+	for i := range targets {
+		t := &targets[i]
+		if opts.Overwrite {
+			t.Overwrite = true
+		}
+		if len(opts.Names) > 0 && len(opts.Data) > 0 {
+			path, err := filepath.Abs(opts.Data)
+			if err != nil {
+				return nil, errors.WrapWithCode(err, errors.ErrCodeFile, fmt.Sprintf("无法解析数据文件路径: %s", err))
+			}
+			t.Data = path
+			continue
+		}
+		if len(t.Data) == 0 {
+			return nil, errors.New(errors.ErrCodeConfig, fmt.Sprintf("模板 %s 未配置数据文件，请通过 --data 指定", t.Name))
+		}
+		if err := utils.FixFilepathByProjectDir(&t.Data); err != nil {
+			return nil, errors.WrapWithCode(err, errors.ErrCodeFile, fmt.Sprintf("无法解析数据文件路径: %s", err))
+		}
+	}
+	return targets, nil
+}
+
+// RunAutoTemplateRender function    执行数据模板渲染操作.
+func RunAutoTemplateRender(opts *TemplateOptions) {
+	// 日志输出到标准错误，避免混入输出到标准输出的渲染结果
+	logger.SetOutput(os.Stderr)
+	config.ExecuteWithConfig(func(cfg config.Option) error {
+		return TemplateRender(context.Background(), opts, cfg)
+	})
+}
@@ -3,7 +3,6 @@ package runner
 import (
 	"context"
 	"fmt"
-	"path/filepath"
 
 	"github.com/spelens-gud/gsus/internal/config"
 	"github.com/spelens-gud/gsus/internal/errors"
@@ -12,37 +11,38 @@ import (
 	"github.com/spelens-gud/gsus/internal/parser"
 	"github.com/spelens-gud/gsus/internal/template"
 	"github.com/spelens-gud/gsus/internal/utils"
-	"github.com/spelens-gud/gsus/internal/validator"
 )
 
 // RouterOptions struct    HTTP 路由生成选项.
 type RouterOptions struct {
-	RouterPath string // 路由路径
+	RouterPath string // 路由路径，为空时使用配置
 }
 
 // Router function    执行 HTTP 路由代码生成.
-func Router(ctx context.Context, opts *RouterOptions) error {
+// 扫描 Http.Scope 下的服务定义，使用 Http.Router.Template 模板在 Http.Router.Path 下生成路由代码.
+func Router(ctx context.Context, opts *RouterOptions, cfg config.Option) error {
 	log := logger.WithPrefix("[router]")
 	log.Info("开始执行 HTTP 路由代码生成")
-	// 验证参数
-	if err := validator.ValidateRequired(opts.RouterPath, "router path"); err != nil {
-		log.Error("未能验证路由器路径")
-		return errors.WrapWithCode(err, errors.ErrCodeParse, fmt.Sprintf("未能验证路由器路径: %s", err))
-	}
 
-	routerPath := opts.RouterPath
-	if len(routerPath) == 0 {
-		routerPath = "./api"
+	routerConfig := cfg.Http.Router
+	if len(opts.RouterPath) > 0 {
+		routerConfig.Path = opts.RouterPath
+	}
+	if len(routerConfig.Path) == 0 {
+		routerConfig.Path = "./api"
 	}
+	routerPath := routerConfig.Path
 
 	// 修正路径
 	if err := utils.FixFilepathByProjectDir(&routerPath); err != nil {
 		log.Error("无法解析路由器路径")
 		return errors.WrapWithCode(err, errors.ErrCodeFile, fmt.Sprintf("无法解析路由器路径: %s", err))
 	}
+	// 路由目录下存在旧版 .gsus.router.tmpl 时继续使用
+	routerConfig.Template = legacyTemplate(log, routerConfig.Template, "http_router", routerPath, ".gsus.router")
 
 	// 搜索服务
-	svc, err := SearchServices("./")
+	svc, err := SearchServices(httpScope(cfg))
 	if err != nil {
 		log.Error("搜索服务失败")
 		return errors.WrapWithCode(err, errors.ErrCodeParse, fmt.Sprintf("搜索服务失败: %s", err))
@@ -56,8 +56,7 @@ func Router(ctx context.Context, opts *RouterOptions) error {
 	}
 
 	// 加载模板
-	templatePath := filepath.Join(routerPath, ".gsus.router"+config.GsusTemplateSuffix)
-	customTemplate, hash, err := template.InitAndLoad(templatePath, template.DefaultHttpRouterTemplate)
+	customTemplate, hash, err := template.Load(routerConfig.Template)
 	if err != nil {
 		log.Error("加载路由器模板失败")
 		return errors.WrapWithCode(err, errors.ErrCodeTemplate, fmt.Sprintf("加载路由器模板失败: %s", err))
@@ -78,7 +77,7 @@ func Router(ctx context.Context, opts *RouterOptions) error {
 
 // RunAutoRouter function    执行 HTTP 路由代码生成（兼容旧接口）.
 func RunAutoRouter(opts *RouterOptions) {
-	config.ExecuteWithConfig(func(_ config.Option) error {
-		return Router(context.Background(), opts)
+	config.ExecuteWithConfig(func(cfg config.Option) error {
+		return Router(context.Background(), opts, cfg)
 	})
 }
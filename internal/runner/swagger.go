@@ -0,0 +1,83 @@
+package runner
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/spelens-gud/gsus/internal/config"
+	"github.com/spelens-gud/gsus/internal/errors"
+	"github.com/spelens-gud/gsus/internal/generator"
+	"github.com/spelens-gud/gsus/internal/logger"
+	"github.com/spelens-gud/gsus/internal/parser"
+	"github.com/spelens-gud/gsus/internal/utils"
+)
+
+// SwaggerOptions struct    接口文档生成选项.
+type SwaggerOptions struct {
+	Path string // 文档输出路径，为空时使用配置
+}
+
+// Swagger function    执行接口文档生成.
+func Swagger(ctx context.Context, opts *SwaggerOptions, cfg config.Option) error {
+	log := logger.WithPrefix("[swagger]")
+	log.Info("开始执行接口文档生成")
+
+	swaggerConfig := cfg.Http.Swagger
+	if len(opts.Path) > 0 {
+		swaggerConfig.Path = opts.Path
+	}
+	if len(swaggerConfig.Path) == 0 {
+		swaggerConfig.Path = "docs/swagger.json"
+		log.Debug("使用默认文档路径: %s", swaggerConfig.Path)
+	}
+
+	// 修正路径
+	if err := utils.FixFilepathByProjectDir(&swaggerConfig.Path); err != nil {
+		log.Error("无法解析文档路径")
+		return errors.WrapWithCode(err, errors.ErrCodeFile, fmt.Sprintf("无法解析文档路径: %s", err))
+	}
+	if len(swaggerConfig.MainApiPath) > 0 {
+		if err := utils.FixFilepathByProjectDir(&swaggerConfig.MainApiPath); err != nil {
+			log.Error("无法解析文档头部信息路径")
+			return errors.WrapWithCode(err, errors.ErrCodeFile, fmt.Sprintf("无法解析文档头部信息路径: %s", err))
+		}
+	}
+
+	// 搜索服务
+	svc, err := SearchServices(httpScope(cfg))
+	if err != nil {
+		log.Error("搜索服务失败")
+		return errors.WrapWithCode(err, errors.ErrCodeParse, fmt.Sprintf("搜索服务失败: %s", err))
+	}
+
+	// 解析 API
+	apiGroups, err := parser.ParseApiFromService(svc)
+	if err != nil {
+		log.Error("无法从服务解析API")
+		return errors.WrapWithCode(err, errors.ErrCodeParse, fmt.Sprintf("无法从服务解析API: %s", err))
+	}
+
+	// 生成文档
+	if err := generator.GenSwagger(apiGroups, generator.SwaggerConfig{
+		Path:        swaggerConfig.Path,
+		MainApiPath: swaggerConfig.MainApiPath,
+		Success:     swaggerConfig.Success,
+		Failed:      swaggerConfig.Failed,
+		ProduceType: swaggerConfig.ProduceType,
+		Version:     swaggerConfig.Version,
+		TypeScope:   swaggerConfig.TypeScope,
+	}); err != nil {
+		log.Error("生成接口文档失败")
+		return errors.WrapWithCode(err, errors.ErrCodeGenerate, fmt.Sprintf("生成接口文档失败: %s", err))
+	}
+
+	log.Info("生成接口文档成功: %s", swaggerConfig.Path)
+	return nil
+}
+
+// RunAutoSwagger function    执行接口文档生成（兼容旧接口）.
+func RunAutoSwagger(opts *SwaggerOptions) {
+	config.ExecuteWithConfig(func(cfg config.Option) error {
+		return Swagger(context.Background(), opts, cfg)
+	})
+}
@@ -5,11 +5,13 @@ import (
 	"fmt"
 	"os"
 	"strings"
+	"text/tabwriter"
 
 	"github.com/spelens-gud/gsus/internal/config"
 	"github.com/spelens-gud/gsus/internal/errors"
 	"github.com/spelens-gud/gsus/internal/generator"
 	"github.com/spelens-gud/gsus/internal/logger"
+	"github.com/spelens-gud/gsus/internal/template"
 	"github.com/spelens-gud/gsus/internal/utils"
 	"github.com/stoewer/go-strcase"
 )
@@ -19,6 +21,9 @@ type TemplateOptions struct {
 	Models    []string // 模型名称列表
 	GenAll    bool     // 是否生成所有模型
 	Overwrite bool     // 是否覆盖已存在的文件
+	Names     []string // 模板名称列表
+	Data      string   // 数据文件路径
+	Path      string   // 输出路径模板
 }
 
 func Template(ctx context.Context, opts *TemplateOptions) error {
@@ -49,8 +54,19 @@ func Template(ctx context.Context, opts *TemplateOptions) error {
 		}
 	}
 
+	if len(models) == 0 {
+		log.Warn("未指定模型，请传入模型名称或使用 --all 生成所有模型")
+		return nil
+	}
+
+	templates, err := loadTemplateFiles(modelTemplates(cfg.Templates.Templates))
+	if err != nil {
+		log.Error("加载模板失败")
+		return err
+	}
+
 	for _, model := range models {
-		if err = processModel(model, cfg, opts); err != nil {
+		if err = processModel(model, cfg, templates, opts); err != nil {
 			log.Error("生成模板代码失败")
 			return errors.WrapWithCode(err, errors.ErrCodeGenerate, fmt.Sprintf("生成模板代码失败: %s", err))
 		}
@@ -67,6 +83,90 @@ func RunAutoTemplate(opts *TemplateOptions) {
 	})
 }
 
+// TemplateFuncs function    输出所有模板可用的函数及用法.
+func TemplateFuncs(ctx context.Context, opts *TemplateOptions) error {
+	w := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+	_, _ = fmt.Fprintln(w, "函数\t用法\t说明")
+	for _, f := range utils.TemplateFuncs() {
+		_, _ = fmt.Fprintf(w, "%s\t%s\t%s\n", f.Name, f.Usage, f.Desc)
+	}
+	if err := w.Flush(); err != nil {
+		return errors.WrapWithCode(err, errors.ErrCodeFile, fmt.Sprintf("输出模板函数失败: %s", err))
+	}
+	return nil
+}
+
+// RunAutoTemplateFuncs function    输出所有模板可用的函数及用法.
+func RunAutoTemplateFuncs(opts *TemplateOptions) {
+	utils.Execute(func() error {
+		return TemplateFuncs(context.Background(), opts)
+	})
+}
+
+// TemplateEject function    将用户模板或内置模板导出到项目 .gsus/templates 下以便自定义.
+func TemplateEject(ctx context.Context, opts *TemplateOptions) error {
+	log := logger.WithPrefix("[template]")
+	if len(opts.Names) == 0 {
+		return errors.New(errors.ErrCodeTemplate, "请指定要导出的模板名称，执行 gsus template list 查看可用模板")
+	}
+	for _, name := range opts.Names {
+		src, path, err := template.Eject(name, opts.Overwrite)
+		if err != nil {
+			log.Error("导出模板 %s 失败", name)
+			return err
+		}
+		log.Info("已导出%s模板 %s: %s", templateLayerName(src.Layer), src.Name, path)
+	}
+	return nil
+}
+
+// RunAutoTemplateEject function    执行模板导出操作.
+func RunAutoTemplateEject(opts *TemplateOptions) {
+	utils.Execute(func() error {
+		return TemplateEject(context.Background(), opts)
+	})
+}
+
+// TemplateList function    输出所有可用模板及其生效的来源.
+func TemplateList(ctx context.Context, opts *TemplateOptions) error {
+	sources, err := template.List()
+	if err != nil {
+		return err
+	}
+	w := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+	_, _ = fmt.Fprintln(w, "名称\t来源\t路径\t覆盖")
+	for _, src := range sources {
+		shadows := make([]string, 0, len(src.Shadows))
+		for _, layer := range src.Shadows {
+			shadows = append(shadows, templateLayerName(layer))
+		}
+		_, _ = fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", src.Name, templateLayerName(src.Layer), src.Path, strings.Join(shadows, ","))
+	}
+	if err = w.Flush(); err != nil {
+		return errors.WrapWithCode(err, errors.ErrCodeFile, fmt.Sprintf("输出模板列表失败: %s", err))
+	}
+	return nil
+}
+
+// RunAutoTemplateList function    输出所有可用模板.
+func RunAutoTemplateList(opts *TemplateOptions) {
+	utils.Execute(func() error {
+		return TemplateList(context.Background(), opts)
+	})
+}
+
+// templateLayerName function    返回模板层级的显示名称.
+func templateLayerName(layer template.Layer) string {
+	switch layer {
+	case template.LayerProject:
+		return "项目"
+	case template.LayerUser:
+		return "用户"
+	default:
+		return "内置"
+	}
+}
+
 func collectModelsFromPath(modelPath string) ([]string, error) {
 	models := make([]string, 0)
 	info, err := os.ReadDir(modelPath)
@@ -83,11 +183,39 @@ func collectModelsFromPath(modelPath string) ([]string, error) {
 	return models, nil
 }
 
-func processModel(model string, cfg config.Option, opts *TemplateOptions) error {
+// loadTemplateFiles function    加载配置的模板，未指定模板文件时使用与名称同名的模板.
+func loadTemplateFiles(templates []config.Template) ([]generator.TemplateFile, error) {
+	files := make([]generator.TemplateFile, 0, len(templates))
+	for _, t := range templates {
+		name := t.Template
+		if len(name) == 0 {
+			name = t.Name
+		}
+		content, _, err := template.Load(name)
+		if err != nil {
+			return nil, errors.WrapWithCode(err, errors.ErrCodeTemplate, fmt.Sprintf("加载模板 %s 失败: %s", name, err))
+		}
+		files = append(files, generator.TemplateFile{Template: t, Content: content})
+	}
+	return files, nil
+}
+
+// modelTemplates function    返回基于模型渲染的模板，设置了数据文件的模板由 gsus template render 生成.
+func modelTemplates(templates []config.Template) []config.Template {
+	list := make([]config.Template, 0, len(templates))
+	for _, t := range templates {
+		if len(t.Data) == 0 {
+			list = append(list, t)
+		}
+	}
+	return list
+}
+
+func processModel(model string, cfg config.Option, templates []generator.TemplateFile, opts *TemplateOptions) error {
 	return generator.GenTemplate(generator.TemplateConfig{
 		ModelPath: cfg.Templates.ModelPath,
 		ModelName: strcase.SnakeCase(model),
-		Templates: cfg.Templates.Templates,
+		Templates: templates,
 		Overwrite: opts.Overwrite,
 	}, cfg)
 }
@@ -2,35 +2,169 @@ package runner
 
 import (
 	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
 
 	"github.com/spelens-gud/gsus/internal/config"
+	"github.com/spelens-gud/gsus/internal/errors"
 	"github.com/spelens-gud/gsus/internal/logger"
+	"github.com/spelens-gud/gsus/internal/template"
+	"github.com/spelens-gud/gsus/internal/template/builtin"
+	"github.com/spelens-gud/gsus/internal/utils"
 )
 
 // UpdateOptions struct    更新选项.
 type UpdateOptions struct {
-	// 预留扩展字段
+	DryRun bool // 仅输出更新结果，不写入文件
 }
 
+// updateResult struct    单个模板的更新结果.
+type updateResult struct {
+	Name      string // 模板名称
+	Status    string // 更新状态
+	Conflicts int    // 冲突数量
+}
+
+// 模板更新状态.
+const (
+	updateBuiltin   = "使用内置"
+	updateUpToDate  = "已是最新"
+	updateUpdated   = "已更新"
+	updateMerged    = "已合并"
+	updateConflict  = "存在冲突"
+	updateNoBase    = "缺少原始版本"
+	updateCustomize = "保留自定义"
+)
+
+// Update function    更新项目模板.
+// 以导出时记录的原始模板为基准，将新版内置模板的改动三路合并到 .gsus/templates 下已导出的模板中，
+// 未导出的模板直接使用内置版本无需更新，双方修改重叠时写入冲突标记，最后输出更新汇总.
 func Update(ctx context.Context, opts *UpdateOptions) error {
 	log := logger.WithPrefix("[update]")
-	log.Info("开始执行 update 代码生成")
+	log.Info("开始执行模板更新")
 
-	cfg, err := config.Get()
+	if _, err := config.Load(config.ConfigFile()); err != nil {
+		log.Error("未找到项目 gsus 配置")
+		return errors.WrapWithCode(err, errors.ErrCodeConfig, "加载项目 gsus 配置失败，请运行 [gsus init] 来初始化项目 gsus 配置")
+	}
+	dir, err := utils.GetProjectDir()
 	if err != nil {
-		return err
+		return errors.WrapWithCode(err, errors.ErrCodeConfig, fmt.Sprintf("获取项目目录失败: %s", err))
 	}
 
-	// 更新操作的业务逻辑
-	_ = cfg
+	var results []updateResult
+	for _, name := range builtin.Names() {
+		path := filepath.Join(dir, config.GsusTemplateDir, name+config.GsusTemplateSuffix)
+		result, err := updateTemplate(name, path, builtin.MustRead(name), opts.DryRun)
+		if err != nil {
+			log.Error("更新模板 %s 失败", name)
+			return errors.WrapWithCode(err, errors.ErrCodeTemplate, fmt.Sprintf("更新模板 %s 失败: %s", name, err))
+		}
+		results = append(results, result)
+	}
 
-	log.Info("更新操作完成")
+	reportUpdate(log, results, opts.DryRun)
 	return nil
 }
 
+// updateTemplate function    三路合并单个模板，返回更新结果.
+func updateTemplate(name, path, latest string, dryRun bool) (result updateResult, err error) {
+	result.Name = name
+	write := func(content string) error {
+		if dryRun {
+			return nil
+		}
+		if err := os.MkdirAll(filepath.Dir(path), 0o775); err != nil {
+			return err
+		}
+		if err := os.WriteFile(path, []byte(content), 0o664); err != nil {
+			return err
+		}
+		return template.WriteBase(path, latest)
+	}
+
+	current, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		result.Status = updateBuiltin
+		return result, nil
+	}
+	if err != nil {
+		return result, err
+	}
+
+	base, err := os.ReadFile(template.BasePath(path))
+	if os.IsNotExist(err) {
+		// 早期版本初始化的项目没有记录原始模板，仅在内容与新版一致时补记
+		if string(current) == latest {
+			result.Status = updateUpToDate
+			return result, write(latest)
+		}
+		result.Status = updateNoBase
+		return result, nil
+	}
+	if err != nil {
+		return result, err
+	}
+
+	switch {
+	case string(current) == latest:
+		result.Status = updateUpToDate
+		if string(base) != latest {
+			return result, write(latest)
+		}
+		return result, nil
+	case string(base) == latest:
+		result.Status = updateCustomize
+		return result, nil
+	case string(current) == string(base):
+		result.Status = updateUpdated
+		return result, write(latest)
+	}
+
+	merged, conflicts := template.Merge(string(base), string(current), latest)
+	result.Status, result.Conflicts = updateMerged, conflicts
+	if conflicts > 0 {
+		result.Status = updateConflict
+	}
+	return result, write(merged)
+}
+
+// reportUpdate function    输出模板更新汇总.
+func reportUpdate(log logger.Logger, results []updateResult, dryRun bool) {
+	counts := make(map[string]int)
+	for _, r := range results {
+		counts[r.Status]++
+		switch r.Status {
+		case updateConflict:
+			log.Warn("%s: %s %d 处，请手动处理 <<<<<<< 冲突标记", r.Name, r.Status, r.Conflicts)
+		case updateNoBase:
+			log.Warn("%s: %s，无法自动合并，请手动对比新版内置模板", r.Name, r.Status)
+		case updateUpToDate, updateCustomize, updateBuiltin:
+			log.Debug("%s: %s", r.Name, r.Status)
+		default:
+			log.Info("%s: %s", r.Name, r.Status)
+		}
+	}
+
+	var summary []string
+	for _, status := range []string{updateUpdated, updateMerged, updateConflict, updateNoBase, updateCustomize, updateUpToDate, updateBuiltin} {
+		if counts[status] > 0 {
+			summary = append(summary, fmt.Sprintf("%s %d", status, counts[status]))
+		}
+	}
+	if dryRun {
+		log.Info("模板更新预览（未写入文件）: %s", strings.Join(summary, "，"))
+		return
+	}
+	log.Info("模板更新完成: %s", strings.Join(summary, "，"))
+}
+
 // RunAutoUpdate function    执行更新操作.
+// 不要求配置通过校验，便于恢复缺失的模板.
 func RunAutoUpdate(opts *UpdateOptions) {
-	config.ExecuteWithConfig(func(_ config.Option) (err error) {
+	utils.Execute(func() error {
 		return Update(context.Background(), opts)
 	})
 }
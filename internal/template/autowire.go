@@ -0,0 +1,6 @@
+package template
+
+import "github.com/spelens-gud/gsus/internal/template/builtin"
+
+// DefaultAutowireTemplate 依赖注入代码模板.
+var DefaultAutowireTemplate = builtin.MustRead("autowire")
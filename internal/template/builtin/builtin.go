@@ -0,0 +1,45 @@
+// Package builtin 提供随 gsus 发布的内置默认模板.
+// 模板按 项目 .gsus/templates → 用户 ~/.config/gsus/templates → 内置模板 的顺序查找，内置模板仅在前两者均不存在时使用.
+package builtin
+
+import (
+	"embed"
+	"io/fs"
+	"sort"
+	"strings"
+)
+
+// suffix 模板文件后缀.
+const suffix = ".tmpl"
+
+//go:embed *.tmpl
+var files embed.FS
+
+// Names function    返回所有内置模板名称，按名称排序.
+func Names() []string {
+	entries, _ := fs.ReadDir(files, ".")
+	names := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		names = append(names, strings.TrimSuffix(entry.Name(), suffix))
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Read function    读取内置模板内容，模板不存在时返回 false.
+func Read(name string) (string, bool) {
+	data, err := files.ReadFile(strings.TrimSuffix(name, suffix) + suffix)
+	if err != nil {
+		return "", false
+	}
+	return string(data), true
+}
+
+// MustRead function    读取内置模板内容，模板不存在时 panic，用于初始化默认模板变量.
+func MustRead(name string) string {
+	content, ok := Read(name)
+	if !ok {
+		panic("builtin template not found: " + name)
+	}
+	return content
+}
@@ -2,6 +2,8 @@ package template
 
 // language=yaml
 const DefaultConfigYaml = `# go-gsus config
+# 所有值均支持 ${VAR} 及 ${VAR:-default} 形式引用环境变量
+# 同目录下的 config.local.yaml 会深度合并到本文件之上 该文件已被 .gitignore 忽略 适合存放数据库密码等本地配置
 gsus:
   origin:
 
@@ -28,6 +30,7 @@ impls:
 # client 客户端生成
 # swagger 接口文档生成
 # ${scope}为搜索目录 将会搜索所有 带@service 的interface以下带@http注解的方法
+# 执行 gsus http 会依次完成以上三项生成 也可通过 gsus http router/client/swagger 单独执行
 http:
   scope: service
 
@@ -49,7 +52,7 @@ http:
     path: api
 
 
-  # 接口文档生成遵循swagger(openapi2.0)规范
+  # 接口文档默认遵循swagger(openapi2.0)规范 可通过${version}切换为openapi3.1
   # 详情请查阅https://github.com/swaggo/swag注解规范
   swagger:
     # ${path}指定生成swagger.json文件名
@@ -60,6 +63,12 @@ http:
     success: 200 {object} object{data={{ .Response }},ok=bool}
     # ${failed}为失败返回时的应答格式
     failed: 400,500 {object} object{message=string,ok=bool,code=int} "failed"
+    # ${produceType}为响应内容类型 支持json/xml/plain/html或完整MIME类型
+    produceType: json
+    # ${version}为文档版本 支持2.0/3.1 3.1输出openapi3.1文档及json schema 2020-12数据结构
+    version: "2.0"
+    # ${typeScope}指定请求/响应数据类型的扫描范围 默认为项目目录
+    typeScope: ./
 
 
 # 表生成工具能够快速地将sql表结构生成为代码model结构体 并生成泛型调用方法
@@ -77,7 +86,11 @@ db2struct:
   # ${genericTemplate}指定泛型生成模板 可自定义
   genericTemplate: model_generic
 
+  # ${enumType}为 MySQL ENUM/SET 列及 PostgreSQL 枚举列生成具名枚举类型 包含常量、校验及 Scan/Value
+  enumType: true
+
   # 以下参数为数据库连接参数 请使用测试或本地库进行生成
+  # 密码等敏感信息建议写在 config.local.yaml 中 或通过 ${DB_PASSWORD} 形式引用环境变量
   user:
   password:
   host:
@@ -96,7 +109,17 @@ templates:
   # 生成模板列表
   # ${name} 会影响生成模板和生成中的一些默认值
   # ${path} 指定生成的文件名 通过规则渲染而成
-  # ${overwrite} 指定是否覆盖生成 如不使用覆盖生成 在已有相同文件时会使用 .bak后缀
+  # 模板及路径模板中可使用 snake、plural 等函数 执行 gsus template funcs 查看全部函数
+  # 模板数据中 .Fields 含 Column、SQLType、Default、Nullable、PrimaryKey、Indexes、JSON、Tags、Gorm 等读取自结构体标签的字段元数据 .PrimaryKey 为主键字段
+  # 模板中访问映射不存在的键会报错 可选的键使用 {{ index .Options "key" }} 获取 执行 gsus template check 可使用示例数据检查所有模板
+  # .gsus/templates 下的模板及 _partials 目录中的公共片段解析为同一模板集合 可通过 {{ template "name" . }} 互相引用 同名定义会报错
+  # ${overwrite} 指定是否覆盖生成 如不使用覆盖生成 在已有相同文件时会先将原文件备份为 .bak后缀
+  # ${template} 指定使用的模板 默认使用与${name}同名的模板
+  # 模板按 项目 .gsus/templates → 用户 ~/.config/gsus/templates → 内置模板 的顺序查找 执行 gsus template list 查看来源
+  # 需要自定义内置模板时 执行 gsus template eject ${name} 导出到 .gsus/templates 后修改
+  # 通过 gsus template pack install 安装到 .gsus/packs 的模板包 可使用 包名:模板 引用 如 template: platform:dao
+  # ${data} 指定 YAML/JSON 数据文件 设置后该模板不再按模型生成 而是执行 gsus template render 以数据文件内容为根数据 . 渲染模板及路径
+  # 可用于功能开关、错误码表、权限列表等声明式数据 也可执行 gsus template render ${template} --data spec.yaml -o ${path} 临时渲染
   templates:
     - name: service
       path: service/{{ .PackageName }}.go
@@ -110,9 +133,14 @@ templates:
       path: internal/model/{{ .PackageName }}_cast.go
 
 # autowire 会自动搜索 @autowire 注解并自动生成依赖注入
+# 注解格式为 @autowire(pkg.Interface,set=xxx) 结构体中导出的接口字段会自动注入 每个set生成一个 Initialize${Set} 函数
+# ${scope}为搜索目录
 # ${path}指定生成依赖注入文件的目录
+# ${template}指定依赖注入生成模板 可自定义
 autowire:
+  scope:
   path: ./cmd/inject
+  template: autowire
 
 
 # mount 会搜索 @${name} 注解的结构体或初始函数 并将对应类型挂载到指定结构体的字段
@@ -127,8 +155,32 @@ mounts:
     struct: Service
 
 
+# enum 会搜索 @enum 注解的常量块 并生成 String/Parse/Values/IsValid/JSON/Text 方法
+# 常量块需要声明类型 如 const ( StatusPending Status = iota ... )
+# 枚举值默认使用去除类型名前缀后的蛇形命名作为字符串表示 可以通过 @label(xxx) 注释指定
+# 使用 @enum(store=string) 或 @enum(store=int) 会额外生成 Scan/Value 方法 使枚举可直接作为 model 字段存储
+# ${scope}为搜索目录
+# ${path}指定生成的文件名 通过规则渲染而成 相对路径基于枚举类型所在目录
+# ${template}指定枚举生成模板 可自定义
 enum:
   scope:
-  path:
-  template:
+  path: "{{ .SnakeName }}_enum.go"
+  template: enum
+
+
+# profiles 为命名配置 每个 profile 可以覆盖以上任意配置项 映射逐项合并 列表整体替换
+# 通过 gsus --profile ${name} 或 GSUS_PROFILE 环境变量选择 例如:
+# profiles:
+#   local:
+#     db2struct:
+#       type: sqlite
+#       db: ./local.db
+#   staging:
+#     db2struct:
+#       type: mysql
+#       host: staging-db.internal
+#       port: 3306
+#       password: ${STAGING_DB_PASSWORD}
+#       path: ./internal/model_staging
+profiles:
 `
@@ -0,0 +1,231 @@
+package template
+
+import "github.com/spelens-gud/gsus/internal/template/builtin"
+
+// DefaultEnumTemplate 枚举代码模板，包含 package 声明及 EnumMethodsTemplate 中的方法.
+var DefaultEnumTemplate = builtin.MustRead("enum")
+
+// EnumMethodsTemplate 枚举方法模板，不包含 package 声明，可追加到已有文件中.
+const EnumMethodsTemplate = `{{ $type := .TypeName }}{{ $caller := .CallerIdent }}
+// Invalid{{ $type }}Error 非法的 {{ $type }} 值.
+type Invalid{{ $type }}Error struct {
+	Value interface{}
+}
+
+// Error 实现 error 接口.
+func (e *Invalid{{ $type }}Error) Error() string {
+	return fmt.Sprintf("invalid {{ $type }}: %v", e.Value)
+}
+
+// {{ $type }}Values 返回 {{ $type }} 的全部枚举值.
+func {{ $type }}Values() []{{ $type }} {
+	return []{{ $type }}{ {{ range .Values }}
+		{{ .Name }},{{ end }}
+	}
+}
+
+// Parse{{ $type }} 将字符串解析为 {{ $type }}.
+func Parse{{ $type }}(s string) ({{ $type }}, error) {
+	switch s { {{ range .Values }}
+	case {{ printf "%q" .Label }}:
+		return {{ .Name }}, nil{{ end }}
+	}
+	var zero {{ $type }}
+	return zero, &Invalid{{ $type }}Error{Value: s}
+}
+
+// String 返回枚举值的字符串表示.
+func ({{ $caller }} {{ $type }}) String() string {
+	switch {{ $caller }} { {{ range .Values }}
+	case {{ .Name }}:
+		return {{ printf "%q" .Label }}{{ end }}
+	}
+	return fmt.Sprintf("{{ $type }}(%v)", {{ .BaseType }}({{ $caller }}))
+}
+
+// IsValid 判断是否为合法的枚举值.
+func ({{ $caller }} {{ $type }}) IsValid() bool {
+	switch {{ $caller }} { {{ range .Values }}
+	case {{ .Name }}:
+		return true{{ end }}
+	}
+	return false
+}
+
+// MarshalText 实现 encoding.TextMarshaler.
+func ({{ $caller }} {{ $type }}) MarshalText() ([]byte, error) {
+	if !{{ $caller }}.IsValid() {
+		return nil, &Invalid{{ $type }}Error{Value: {{ .BaseType }}({{ $caller }})}
+	}
+	return []byte({{ $caller }}.String()), nil
+}
+
+// UnmarshalText 实现 encoding.TextUnmarshaler.
+func ({{ $caller }} *{{ $type }}) UnmarshalText(text []byte) error {
+	val, err := Parse{{ $type }}(string(text))
+	if err != nil {
+		return err
+	}
+	*{{ $caller }} = val
+	return nil
+}
+
+// MarshalJSON 实现 json.Marshaler.
+func ({{ $caller }} {{ $type }}) MarshalJSON() ([]byte, error) {
+	text, err := {{ $caller }}.MarshalText()
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(string(text))
+}
+
+// UnmarshalJSON 实现 json.Unmarshaler.
+func ({{ $caller }} *{{ $type }}) UnmarshalJSON(data []byte) error {
+	var str string
+	if err := json.Unmarshal(data, &str); err != nil {
+		return fmt.Errorf("{{ $type }} should be a string, got %s", data)
+	}
+	return {{ $caller }}.UnmarshalText([]byte(str))
+}
+{{ if eq .Store "string" }}
+// Value 实现 driver.Valuer，以字符串形式存储.
+func ({{ $caller }} {{ $type }}) Value() (driver.Value, error) {
+	if !{{ $caller }}.IsValid() {
+		return nil, &Invalid{{ $type }}Error{Value: {{ .BaseType }}({{ $caller }})}
+	}
+	return {{ $caller }}.String(), nil
+}
+
+// Scan 实现 sql.Scanner，非法值返回 *Invalid{{ $type }}Error.
+func ({{ $caller }} *{{ $type }}) Scan(src interface{}) error {
+	var raw string
+	switch value := src.(type) {
+	case string:
+		raw = value
+	case []byte:
+		raw = string(value)
+	default:
+		return &Invalid{{ $type }}Error{Value: src}
+	}
+	parsed, err := Parse{{ $type }}(raw)
+	if err != nil {
+		return err
+	}
+	*{{ $caller }} = parsed
+	return nil
+}
+
+// GormDataType 返回 GORM 使用的数据类型.
+func ({{ $type }}) GormDataType() string {
+	return "string"
+}
+{{ else if eq .Store "int" }}
+// Value 实现 driver.Valuer，以整数形式存储.
+func ({{ $caller }} {{ $type }}) Value() (driver.Value, error) {
+	if !{{ $caller }}.IsValid() {
+		return nil, &Invalid{{ $type }}Error{Value: {{ .BaseType }}({{ $caller }})}
+	}
+	return int64({{ $caller }}), nil
+}
+
+// Scan 实现 sql.Scanner，非法值返回 *Invalid{{ $type }}Error.
+func ({{ $caller }} *{{ $type }}) Scan(src interface{}) error {
+	var num int64
+	switch value := src.(type) {
+	case int64:
+		num = value
+	case []byte:
+		parsed, err := strconv.ParseInt(string(value), 10, 64)
+		if err != nil {
+			return &Invalid{{ $type }}Error{Value: src}
+		}
+		num = parsed
+	case string:
+		parsed, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			return &Invalid{{ $type }}Error{Value: src}
+		}
+		num = parsed
+	default:
+		return &Invalid{{ $type }}Error{Value: src}
+	}
+	if parsed := {{ $type }}(num); parsed.IsValid() {
+		*{{ $caller }} = parsed
+		return nil
+	}
+	return &Invalid{{ $type }}Error{Value: src}
+}
+
+// GormDataType 返回 GORM 使用的数据类型.
+func ({{ $type }}) GormDataType() string {
+	return "int"
+}
+{{ end }}`
+
+// EnumDeclTemplate 字符串枚举类型及常量声明模板.
+const EnumDeclTemplate = `
+// {{ .TypeName }} {{ .Comment }}
+type {{ .TypeName }} {{ .BaseType }}
+
+const ( {{ $type := .TypeName }}{{ range .Values }}
+	{{ .Name }} {{ $type }} = {{ printf "%q" .Label }}{{ end }}
+)
+`
+
+// EnumSetTemplate 集合类型模板，用于 MySQL SET 列.
+const EnumSetTemplate = `
+// {{ .SetName }} {{ .TypeName }} 的集合，数据库中以逗号分隔存储.
+type {{ .SetName }} []{{ .TypeName }}
+
+// Has 判断集合是否包含指定值.
+func ({{ .SetCallerIdent }} {{ .SetName }}) Has(item {{ .TypeName }}) bool {
+	for _, elem := range {{ .SetCallerIdent }} {
+		if elem == item {
+			return true
+		}
+	}
+	return false
+}
+
+// Value 实现 driver.Valuer.
+func ({{ .SetCallerIdent }} {{ .SetName }}) Value() (driver.Value, error) {
+	items := make([]string, 0, len({{ .SetCallerIdent }}))
+	for _, elem := range {{ .SetCallerIdent }} {
+		if !elem.IsValid() {
+			return nil, &Invalid{{ .TypeName }}Error{Value: string(elem)}
+		}
+		items = append(items, string(elem))
+	}
+	return strings.Join(items, ","), nil
+}
+
+// Scan 实现 sql.Scanner，非法值返回 *Invalid{{ .TypeName }}Error.
+func ({{ .SetCallerIdent }} *{{ .SetName }}) Scan(src interface{}) error {
+	var raw string
+	switch value := src.(type) {
+	case string:
+		raw = value
+	case []byte:
+		raw = string(value)
+	default:
+		return &Invalid{{ .TypeName }}Error{Value: src}
+	}
+	items := make({{ .SetName }}, 0)
+	if len(raw) > 0 {
+		for _, elem := range strings.Split(raw, ",") {
+			parsed, err := Parse{{ .TypeName }}(elem)
+			if err != nil {
+				return err
+			}
+			items = append(items, parsed)
+		}
+	}
+	*{{ .SetCallerIdent }} = items
+	return nil
+}
+
+// GormDataType 返回 GORM 使用的数据类型.
+func ({{ .SetName }}) GormDataType() string {
+	return "string"
+}
+`
@@ -0,0 +1,18 @@
+package template
+
+// DefaultHttpImportTemplate 根据 OpenAPI 文档生成的服务定义文件模板.
+const DefaultHttpImportTemplate = `// 由 gsus http import 根据 {{ .Source }} 生成，可按需修改.
+
+package {{ .Package }}
+{{ range .Services }}
+// {{ .InterfaceName }} {{ .Title }}
+// @service({{ .Name }},route="{{ .Route }}")
+type {{ .InterfaceName }} interface { {{ range .Methods }}
+	// {{ .Title }}{{ range .Doc }}
+	// {{ . }}{{ end }}
+	// @http.{{ .HttpMethod }}("{{ .Route }}")
+	{{ .Name }}(ctx context.Context{{ if .Param }}, req {{ .Param }}{{ end }}) {{ if .Return }}({{ .Return }}, error){{ else }}error{{ end }}
+{{ end }}}
+{{ end }}{{ range .Decls }}
+{{ . }}
+{{ end }}`
@@ -1,17 +1,6 @@
 package template
 
-const DefaultHttpRouterTemplate = `// Code generated by gsus-http. DO NOT EDIT.
-// Generate Hash: {{ .Hash }}
-package {{.Package}}
+import "github.com/spelens-gud/gsus/internal/template/builtin"
 
-import (
-	svrlessgin "github.com/Just-maple/serverless-gin"
-	"github.com/gin-gonic/gin"
-)
-
-func Register{{ .GroupName }}Group(svc {{ .ServiceName }}, router gin.IRoutes, svcH svrlessgin.GinSvcHandler) {
-	{{ range .Apis }}// {{ .Title }}
-	router.{{ .HttpMethod }}({{ .Route }}, svcH(svc.{{ .Handler }}))
-	{{ end }}
-}
-`
+// DefaultHttpRouterTemplate 路由代码模板.
+var DefaultHttpRouterTemplate = builtin.MustRead("http_router")
@@ -1,15 +1,6 @@
 package template
 
-const (
-	DefaultImplTemplate = `// Code generated by gsus-impl.
-package {{ .ImplPackage }}
+import "github.com/spelens-gud/gsus/internal/template/builtin"
 
-import {{ .InterfacePackageName }} "{{ .InterfacePackagePath }}"
-
-var _ {{ .InterfacePackageName }}.{{ .InterfaceName }} = &{{ .ImplStructName }}{}
-
-// @autowire({{ .InterfacePackageName }}.{{ .InterfaceName }},set={{ .SetName }})
-type {{ .ImplStructName }} struct {
-}
-`
-)
+// DefaultImplTemplate 接口实现代码模板.
+var DefaultImplTemplate = builtin.MustRead("impl")
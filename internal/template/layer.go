@@ -0,0 +1,264 @@
+package template
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/spelens-gud/gsus/internal/config"
+	"github.com/spelens-gud/gsus/internal/errors"
+	"github.com/spelens-gud/gsus/internal/template/builtin"
+	"github.com/spelens-gud/gsus/internal/utils"
+)
+
+// Layer type    模板来源层级.
+type Layer string
+
+const (
+	// LayerProject 项目模板 .gsus/templates.
+	LayerProject Layer = "project"
+	// LayerUser 用户模板 ~/.config/gsus/templates.
+	LayerUser Layer = "user"
+	// LayerBuiltin 内置模板.
+	LayerBuiltin Layer = "builtin"
+	// LayerPack 模板包模板 .gsus/packs，通过 包名:模板 显式引用.
+	LayerPack Layer = "pack"
+)
+
+// Source struct    模板来源.
+type Source struct {
+	Name    string  // 模板名称，公共片段带 _partials/ 前缀
+	Layer   Layer   // 所在层级
+	Pack    string  // 所属模板包，仅模板包模板设置
+	Path    string  // 模板文件路径，内置模板为 builtin:名称.tmpl
+	Content []byte  // 模板内容
+	Shadows []Layer // 被覆盖的较低层级
+}
+
+// layerDir struct    模板目录及其层级.
+type layerDir struct {
+	layer Layer
+	dir   string
+}
+
+// layerDirs function    按优先级从高到低返回项目及用户模板目录，无法获取的目录会被忽略.
+func layerDirs() []layerDir {
+	var dirs []layerDir
+	if dir := config.GsusTemplateDir; utils.FixFilepathByProjectDir(&dir) == nil {
+		dirs = append(dirs, layerDir{layer: LayerProject, dir: dir})
+	}
+	if dir := config.UserTemplateDir(); len(dir) > 0 {
+		dirs = append(dirs, layerDir{layer: LayerUser, dir: dir})
+	}
+	return dirs
+}
+
+// Resolve function    按 项目 .gsus/templates → 用户 ~/.config/gsus/templates → 内置模板 的顺序查找模板，
+// 包名:模板 形式的名称从已安装的模板包中查找.
+func Resolve(name string) (Source, error) {
+	name = strings.TrimSuffix(filepath.ToSlash(name), config.GsusTemplateSuffix)
+	if pack, tmpl, ok := config.ParsePackRef(name); ok {
+		return resolvePack(pack, tmpl)
+	}
+	if err := checkName(name); err != nil {
+		return Source{}, err
+	}
+	for _, d := range layerDirs() {
+		path := filepath.Join(d.dir, filepath.FromSlash(name)+config.GsusTemplateSuffix)
+		data, err := os.ReadFile(path)
+		if err == nil {
+			return Source{Name: name, Layer: d.layer, Path: path, Content: data}, nil
+		}
+		if !os.IsNotExist(err) {
+			return Source{}, errors.WrapWithCode(err, errors.ErrCodeFile, fmt.Sprintf("读取模板 %s 失败: %s", path, err))
+		}
+	}
+	if content, ok := builtin.Read(name); ok {
+		return builtinSource(name, content), nil
+	}
+	return Source{}, errors.New(errors.ErrCodeTemplate, fmt.Sprintf("模板 %s 不存在，执行 gsus template list 查看可用模板", name))
+}
+
+// checkName function    校验模板名称，拒绝绝对路径、包含 .. 及 _partials/ 以外包含目录的名称，避免读写模板目录以外的文件.
+func checkName(name string) error {
+	if !config.ValidTemplateName(name) {
+		return errors.New(errors.ErrCodeTemplate, fmt.Sprintf("模板名称不合法: %q", name))
+	}
+	return nil
+}
+
+// List function    返回所有层级中的模板及公共片段，同名模板仅保留优先级最高的一个并记录被覆盖的层级.
+func List() ([]Source, error) {
+	sources := make(map[string]*Source)
+	add := func(src Source) {
+		if exist, ok := sources[src.Name]; ok {
+			exist.Shadows = append(exist.Shadows, src.Layer)
+			return
+		}
+		sources[src.Name] = &src
+	}
+
+	for _, d := range layerDirs() {
+		files, err := listDir(d.dir)
+		if err != nil {
+			return nil, err
+		}
+		for _, name := range files {
+			path := filepath.Join(d.dir, filepath.FromSlash(name)+config.GsusTemplateSuffix)
+			data, err := os.ReadFile(path)
+			if err != nil {
+				return nil, errors.WrapWithCode(err, errors.ErrCodeFile, fmt.Sprintf("读取模板 %s 失败: %s", path, err))
+			}
+			add(Source{Name: name, Layer: d.layer, Path: path, Content: data})
+		}
+	}
+	for _, name := range builtin.Names() {
+		add(builtinSource(name, builtin.MustRead(name)))
+	}
+
+	list := make([]Source, 0, len(sources))
+	for _, src := range sources {
+		list = append(list, *src)
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].Name < list[j].Name })
+	return list, nil
+}
+
+// listDir function    返回模板目录下的模板及 _partials 目录下的公共片段名称，目录不存在时返回空列表.
+func listDir(dir string) (names []string, err error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, errors.WrapWithCode(err, errors.ErrCodeFile, fmt.Sprintf("读取模板目录失败: %s", err))
+	}
+	for _, entry := range entries {
+		if !entry.IsDir() && strings.HasSuffix(entry.Name(), config.GsusTemplateSuffix) {
+			names = append(names, strings.TrimSuffix(entry.Name(), config.GsusTemplateSuffix))
+		}
+	}
+
+	err = filepath.WalkDir(filepath.Join(dir, config.GsusTemplatePartialsDir), func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			if os.IsNotExist(err) {
+				return filepath.SkipDir
+			}
+			return err
+		}
+		if d.IsDir() || !strings.HasSuffix(path, config.GsusTemplateSuffix) {
+			return nil
+		}
+		rel, err := filepath.Rel(dir, path)
+		if err != nil {
+			return err
+		}
+		names = append(names, strings.TrimSuffix(filepath.ToSlash(rel), config.GsusTemplateSuffix))
+		return nil
+	})
+	if err != nil {
+		return nil, errors.WrapWithCode(err, errors.ErrCodeFile, fmt.Sprintf("读取模板片段目录失败: %s", err))
+	}
+	return names, nil
+}
+
+// templatePosRegexp 匹配模板错误信息中的 template: 模板名:行 位置.
+var templatePosRegexp = regexp.MustCompile(`template: ([^:\s]+):(\d+)`)
+
+// Locate method    将模板错误信息中的 template: 模板名:行:列 替换为模板文件路径:行:列，模板名为同一模板集合中的公共片段时同样适用.
+func (s Source) Locate(err error) string {
+	msg := err.Error()
+	m := templatePosRegexp.FindStringSubmatchIndex(msg)
+	if m == nil {
+		return msg
+	}
+	path := s.lookupFile(msg[m[2]:m[3]])
+	if len(path) == 0 {
+		return msg
+	}
+	return msg[:m[0]] + displayTemplatePath(path) + msg[m[3]:]
+}
+
+// lookupFile method    返回模板集合中指定模板名对应的文件路径，找不到时返回空字符串.
+func (s Source) lookupFile(name string) string {
+	if strings.TrimSuffix(filepath.Base(s.Path), config.GsusTemplateSuffix) == name {
+		return s.Path
+	}
+	var paths []string
+	if len(s.Pack) > 0 {
+		if dir, err := config.PackDir(s.Pack); err == nil {
+			names, _ := listDir(dir)
+			for _, n := range names {
+				paths = append(paths, filepath.Join(dir, filepath.FromSlash(n)+config.GsusTemplateSuffix))
+			}
+		}
+	} else {
+		sources, _ := List()
+		for _, src := range sources {
+			paths = append(paths, src.Path)
+		}
+	}
+	for _, path := range paths {
+		if strings.TrimSuffix(filepath.Base(path), config.GsusTemplateSuffix) == name {
+			return path
+		}
+	}
+	return ""
+}
+
+// builtinSource function    创建内置模板来源.
+func builtinSource(name, content string) Source {
+	return Source{
+		Name:    name,
+		Layer:   LayerBuiltin,
+		Path:    string(LayerBuiltin) + ":" + name + config.GsusTemplateSuffix,
+		Content: []byte(content),
+	}
+}
+
+// Eject function    将用户模板或内置模板导出到项目模板目录以便自定义，返回模板来源及导出路径.
+// 导出内置模板时同时记录原始版本，供 gsus update 三路合并使用.
+func Eject(name string, overwrite bool) (src Source, path string, err error) {
+	name = strings.TrimSuffix(filepath.ToSlash(name), config.GsusTemplateSuffix)
+	if err = checkName(name); err != nil {
+		return src, "", err
+	}
+	for _, d := range layerDirs() {
+		if d.layer != LayerUser {
+			continue
+		}
+		userPath := filepath.Join(d.dir, filepath.FromSlash(name)+config.GsusTemplateSuffix)
+		if data, err := os.ReadFile(userPath); err == nil {
+			src = Source{Name: name, Layer: LayerUser, Path: userPath, Content: data}
+		}
+	}
+	if len(src.Layer) == 0 {
+		content, ok := builtin.Read(name)
+		if !ok {
+			return src, "", errors.New(errors.ErrCodeTemplate, fmt.Sprintf("模板 %s 不存在，执行 gsus template list 查看可用模板", name))
+		}
+		src = builtinSource(name, content)
+	}
+
+	path = filepath.Join(config.GsusTemplateDir, filepath.FromSlash(name)+config.GsusTemplateSuffix)
+	if err = utils.FixFilepathByProjectDir(&path); err != nil {
+		return src, "", errors.WrapWithCode(err, errors.ErrCodeFile, fmt.Sprintf("无法解析模板路径: %s", err))
+	}
+	if _, err = os.Stat(path); err == nil && !overwrite {
+		return src, path, errors.New(errors.ErrCodeTemplate, fmt.Sprintf("模板 %s 已存在: %s，使用 --overwrite 覆盖", name, path))
+	}
+	if err = os.MkdirAll(filepath.Dir(path), 0o775); err != nil {
+		return src, path, errors.WrapWithCode(err, errors.ErrCodeFile, fmt.Sprintf("创建模板目录失败: %s", err))
+	}
+	if err = os.WriteFile(path, src.Content, 0o664); err != nil {
+		return src, path, errors.WrapWithCode(err, errors.ErrCodeFile, fmt.Sprintf("写入模板文件失败: %s", err))
+	}
+	if src.Layer == LayerBuiltin {
+		return src, path, WriteBase(path, string(src.Content))
+	}
+	return src, path, nil
+}
@@ -0,0 +1,103 @@
+package template
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/spelens-gud/gsus/internal/config"
+	"github.com/spelens-gud/gsus/internal/utils"
+)
+
+// TestResolve function    测试按层级查找模板及模板名称校验.
+func TestResolve(t *testing.T) {
+	project := t.TempDir()
+	t.Setenv(utils.ProjectDirEnv, project)
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	writeFiles(t, filepath.Join(project, config.GsusTemplateDir), map[string]string{
+		"dao.tmpl":                 "project dao",
+		"_partials/crud/list.tmpl": "list",
+	})
+	// 模板目录以外的文件
+	writeFiles(t, project, map[string]string{"secret.tmpl": "secret"})
+
+	tests := []struct {
+		name      string
+		tmpl      string
+		wantLayer Layer
+		wantErr   string
+	}{
+		{name: "项目模板覆盖内置模板", tmpl: "dao", wantLayer: LayerProject},
+		{name: "带后缀的名称", tmpl: "dao.tmpl", wantLayer: LayerProject},
+		{name: "公共片段子目录", tmpl: "_partials/crud/list", wantLayer: LayerProject},
+		{name: "内置模板", tmpl: "enum", wantLayer: LayerBuiltin},
+		{name: "不存在的模板", tmpl: "missing", wantErr: "模板 missing 不存在"},
+		{name: "上级目录", tmpl: "../secret", wantErr: `模板名称不合法: "../secret"`},
+		{name: "公共片段中的上级目录", tmpl: "_partials/../../secret", wantErr: "模板名称不合法"},
+		{name: "绝对路径", tmpl: filepath.Join(project, "secret"), wantErr: "模板名称不合法"},
+		{name: "子目录", tmpl: "sub/dao", wantErr: "模板名称不合法"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			src, err := Resolve(tt.tmpl)
+			if len(tt.wantErr) > 0 {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Errorf("Resolve(%q) error = %v, want contains %q", tt.tmpl, err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Resolve(%q) error = %v", tt.tmpl, err)
+			}
+			if src.Layer != tt.wantLayer {
+				t.Errorf("Resolve(%q) layer = %s, want %s", tt.tmpl, src.Layer, tt.wantLayer)
+			}
+		})
+	}
+}
+
+// TestEject function    测试导出模板及模板名称校验.
+func TestEject(t *testing.T) {
+	tests := []struct {
+		name     string
+		tmpl     string
+		wantPath string // 相对项目目录的导出路径
+		wantErr  string
+	}{
+		{name: "导出内置模板", tmpl: "enum", wantPath: ".gsus/templates/enum.tmpl"},
+		{name: "不存在的模板", tmpl: "missing", wantErr: "模板 missing 不存在"},
+		{name: "上级目录", tmpl: "../../evil", wantErr: `模板名称不合法: "../../evil"`},
+		{name: "绝对路径", tmpl: "/tmp/evil", wantErr: "模板名称不合法"},
+		{name: "子目录", tmpl: "sub/enum", wantErr: "模板名称不合法"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			project := t.TempDir()
+			t.Setenv(utils.ProjectDirEnv, project)
+			t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+
+			_, path, err := Eject(tt.tmpl, false)
+			if len(tt.wantErr) > 0 {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Errorf("Eject(%q) error = %v, want contains %q", tt.tmpl, err, tt.wantErr)
+				}
+				if _, err = os.Stat(filepath.Join(project, config.GsusTemplateDir)); !os.IsNotExist(err) {
+					t.Errorf("Eject(%q) should not write templates dir, stat error = %v", tt.tmpl, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Eject(%q) error = %v", tt.tmpl, err)
+			}
+			if want := filepath.Join(project, filepath.FromSlash(tt.wantPath)); path != want {
+				t.Errorf("Eject(%q) path = %s, want %s", tt.tmpl, path, want)
+			}
+			if _, err = os.Stat(path); err != nil {
+				t.Errorf("Eject(%q) did not write template: %v", tt.tmpl, err)
+			}
+		})
+	}
+}
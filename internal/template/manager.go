@@ -2,7 +2,6 @@
 package template
 
 import (
-	"crypto/md5"
 	"fmt"
 	"os"
 	"path/filepath"
@@ -11,8 +10,6 @@ import (
 
 	"github.com/spelens-gud/gsus/internal/config"
 	"github.com/spelens-gud/gsus/internal/errors"
-	"github.com/spelens-gud/gsus/internal/logger"
-	"github.com/spelens-gud/gsus/internal/utils"
 )
 
 // Manager struct    模板管理器.
@@ -27,64 +24,54 @@ func NewManager() *Manager {
 	}
 }
 
-// InitAndLoad function    初始化并加载模板.
-func InitAndLoad(templatePath string, defaultTemplate string) (*template.Template, string, error) {
-	if err := initTemplate(templatePath, defaultTemplate); err != nil {
-		return nil, "", err
+// BasePath function    返回模板的原始版本路径，模板不在模板目录下时返回空字符串.
+func BasePath(templatePath string) string {
+	dir := filepath.Dir(templatePath)
+	if !strings.HasSuffix(dir, config.GsusTemplateDir) {
+		return ""
 	}
-	return Load(templatePath)
+	return filepath.Join(strings.TrimSuffix(dir, config.GsusTemplateDir), config.GsusTemplateBaseDir, filepath.Base(templatePath))
 }
 
-// initTemplate function    初始化模板文件.
-func initTemplate(templatePath string, defaultTemplate string) error {
-	if _, err := os.Stat(templatePath); err != nil {
-		if !os.IsNotExist(err) {
-			return errors.WrapWithCode(err, errors.ErrCodeTemplate, "failed to check template file")
-		}
-
-		logger.Info("template not found, initializing: %s", templatePath)
-		if err := os.MkdirAll(filepath.Dir(templatePath), 0755); err != nil {
-			return errors.WrapWithCode(err, errors.ErrCodeFile, "failed to create template directory")
-		}
-
-		if err := os.WriteFile(templatePath, []byte(defaultTemplate), 0644); err != nil {
-			return errors.WrapWithCode(err, errors.ErrCodeFile, "failed to write template file")
-		}
+// WriteBase function    记录模板的原始版本，供 gsus update 三路合并使用.
+func WriteBase(templatePath string, content string) error {
+	basePath := BasePath(templatePath)
+	if len(basePath) == 0 {
+		return nil
+	}
+	if err := os.MkdirAll(filepath.Dir(basePath), 0755); err != nil {
+		return errors.WrapWithCode(err, errors.ErrCodeFile, fmt.Sprintf("创建原始模板目录失败: %s", err))
+	}
+	if err := os.WriteFile(basePath, []byte(content), 0644); err != nil {
+		return errors.WrapWithCode(err, errors.ErrCodeFile, fmt.Sprintf("写入原始模板失败: %s", err))
 	}
 	return nil
 }
 
-// Load function    加载模板文件.
-func Load(templatePath string) (*template.Template, string, error) {
-	// 添加模板后缀
-	if !strings.HasSuffix(templatePath, config.GsusTemplateSuffix) {
-		templatePath += config.GsusTemplateSuffix
-	}
-
-	// 处理相对路径
+// Find function    查找模板，绝对路径直接读取模板文件，
+// 其他按 项目 .gsus/templates → 用户 ~/.config/gsus/templates → 内置模板 的顺序查找.
+func Find(templatePath string) (Source, error) {
 	if !filepath.IsAbs(templatePath) {
-		templatePath = filepath.Join(config.GsusTemplateDir, templatePath)
-		if err := utils.FixFilepathByProjectDir(&templatePath); err != nil {
-			return nil, "", errors.WrapWithCode(err, errors.ErrCodeTemplate, "failed to resolve template path")
-		}
+		return Resolve(templatePath)
 	}
-
-	// 读取模板文件
 	data, err := os.ReadFile(templatePath)
 	if err != nil {
-		return nil, "", errors.WrapWithCode(err, errors.ErrCodeFile, fmt.Sprintf("failed to read template: %s", templatePath))
+		return Source{}, errors.WrapWithCode(err, errors.ErrCodeFile, fmt.Sprintf("读取模板 %s 失败: %s", templatePath, err))
 	}
+	return Source{
+		Name:    strings.TrimSuffix(filepath.Base(templatePath), config.GsusTemplateSuffix),
+		Path:    templatePath,
+		Content: data,
+	}, nil
+}
 
-	// 计算模板哈希
-	tmplHash := fmt.Sprintf("%x", md5.Sum(data))
-
-	// 解析模板
-	tmpl, err := template.New(filepath.Base(templatePath)).Parse(string(data))
+// Load function    查找并加载模板，与各层级的其他模板及公共片段解析为同一模板集合.
+func Load(templatePath string) (*template.Template, string, error) {
+	src, err := Find(templatePath)
 	if err != nil {
-		return nil, "", errors.WrapWithCode(err, errors.ErrCodeTemplate, "failed to parse template")
+		return nil, "", err
 	}
-
-	return tmpl, tmplHash, nil
+	return parseSet(src)
 }
 
 // LoadByName method    根据名称加载模板.
@@ -107,12 +94,12 @@ func (m *Manager) Get(name string) (*template.Template, bool) {
 func (m *Manager) Render(name string, data interface{}) (string, error) {
 	tmpl, ok := m.Get(name)
 	if !ok {
-		return "", errors.New(errors.ErrCodeTemplate, fmt.Sprintf("template not found: %s", name))
+		return "", errors.New(errors.ErrCodeTemplate, fmt.Sprintf("模板 %s 不存在", name))
 	}
 
 	var buf strings.Builder
 	if err := tmpl.Execute(&buf, data); err != nil {
-		return "", errors.WrapWithCode(err, errors.ErrCodeTemplate, "failed to render template")
+		return "", errors.WrapWithCode(err, errors.ErrCodeTemplate, fmt.Sprintf("渲染模板失败: %s", err))
 	}
 
 	return buf.String(), nil
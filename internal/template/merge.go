@@ -0,0 +1,120 @@
+package template
+
+import (
+	"slices"
+	"strings"
+)
+
+const (
+	// conflictOurs 冲突标记：当前模板.
+	conflictOurs = "<<<<<<< 当前模板\n"
+	// conflictBase 冲突标记：原始模板.
+	conflictBase = "||||||| 原始模板\n"
+	// conflictSep 冲突标记：分隔.
+	conflictSep = "=======\n"
+	// conflictTheirs 冲突标记：新版默认模板.
+	conflictTheirs = ">>>>>>> 新版默认模板\n"
+)
+
+// Merge function    按行三路合并模板.
+// base 为生成时的原始模板，ours 为用户当前模板，theirs 为新版默认模板.
+// 双方修改不重叠时自动合并，重叠且不同时写入冲突标记，返回合并结果及冲突数量.
+func Merge(base, ours, theirs string) (merged string, conflicts int) {
+	o, a, b := splitLines(base), splitLines(ours), splitLines(theirs)
+	matchA, matchB := matchLines(o, a), matchLines(o, b)
+
+	var out []string
+	i, ia, ib := 0, 0, 0
+	for {
+		// 三方一致的部分直接输出
+		k := 0
+		for i+k < len(o) && matchA[i+k] == ia+k && matchB[i+k] == ib+k {
+			k++
+		}
+		if k > 0 {
+			out = append(out, o[i:i+k]...)
+			i, ia, ib = i+k, ia+k, ib+k
+			continue
+		}
+
+		// 查找下一处三方一致的原始行
+		next := i
+		for next < len(o) && (matchA[next] < 0 || matchB[next] < 0) {
+			next++
+		}
+		na, nb := len(a), len(b)
+		if next < len(o) {
+			na, nb = matchA[next], matchB[next]
+		}
+		chunkO, chunkA, chunkB := o[i:next], a[ia:na], b[ib:nb]
+		switch {
+		case slices.Equal(chunkA, chunkO):
+			out = append(out, chunkB...)
+		case slices.Equal(chunkB, chunkO), slices.Equal(chunkA, chunkB):
+			out = append(out, chunkA...)
+		default:
+			conflicts++
+			out = append(out, conflictOurs)
+			out = append(out, chunkA...)
+			out = append(out, conflictBase)
+			out = append(out, chunkO...)
+			out = append(out, conflictSep)
+			out = append(out, chunkB...)
+			out = append(out, conflictTheirs)
+		}
+		if next == len(o) {
+			break
+		}
+		i, ia, ib = next, na, nb
+	}
+	return strings.Join(out, ""), conflicts
+}
+
+// splitLines function    按行拆分文本，保留行尾换行符.
+func splitLines(s string) []string {
+	lines := strings.SplitAfter(s, "\n")
+	if len(lines) > 0 && len(lines[len(lines)-1]) == 0 {
+		lines = lines[:len(lines)-1]
+	}
+	// 最后一行没有换行符时补齐，避免冲突标记与内容连在一起
+	if n := len(lines); n > 0 && !strings.HasSuffix(lines[n-1], "\n") {
+		lines[n-1] += "\n"
+	}
+	return lines
+}
+
+// matchLines function    计算 o 与 a 的最长公共子序列，返回 o 中每一行对应 a 中的行号，未匹配时为 -1.
+func matchLines(o, a []string) []int {
+	// lcs[i][j] 为 o[i:] 与 a[j:] 的最长公共子序列长度
+	lcs := make([][]int, len(o)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(a)+1)
+	}
+	for i := len(o) - 1; i >= 0; i-- {
+		for j := len(a) - 1; j >= 0; j-- {
+			if o[i] == a[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else {
+				lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+			}
+		}
+	}
+
+	match := make([]int, len(o))
+	for i := range match {
+		match[i] = -1
+	}
+	for i, j := 0, 0; i < len(o) && j < len(a); {
+		switch {
+		case o[i] == a[j]:
+			match[i] = j
+			i++
+			j++
+		case lcs[i+1][j] >= lcs[i][j+1]:
+			i++
+		default:
+			j++
+		}
+	}
+	return match
+}
@@ -0,0 +1,201 @@
+package template
+
+import (
+	"slices"
+	"testing"
+)
+
+// TestMerge function    测试按行三路合并模板.
+func TestMerge(t *testing.T) {
+	tests := []struct {
+		name      string
+		base      string
+		ours      string
+		theirs    string
+		want      string
+		conflicts int
+	}{
+		{
+			name:   "三方一致",
+			base:   "a\nb\nc\n",
+			ours:   "a\nb\nc\n",
+			theirs: "a\nb\nc\n",
+			want:   "a\nb\nc\n",
+		},
+		{
+			name:   "仅新版修改",
+			base:   "a\nb\nc\n",
+			ours:   "a\nb\nc\n",
+			theirs: "a\nB\nc\n",
+			want:   "a\nB\nc\n",
+		},
+		{
+			name:   "仅当前修改",
+			base:   "a\nb\nc\n",
+			ours:   "a\nB\nc\n",
+			theirs: "a\nb\nc\n",
+			want:   "a\nB\nc\n",
+		},
+		{
+			name:   "双方修改不重叠",
+			base:   "a\nb\nc\nd\ne\n",
+			ours:   "A\nb\nc\nd\ne\n",
+			theirs: "a\nb\nc\nd\nE\n",
+			want:   "A\nb\nc\nd\nE\n",
+		},
+		{
+			name:   "双方相同修改",
+			base:   "a\nb\nc\n",
+			ours:   "a\nX\nc\n",
+			theirs: "a\nX\nc\n",
+			want:   "a\nX\nc\n",
+		},
+		{
+			name:   "双方修改冲突",
+			base:   "a\nb\nc\n",
+			ours:   "a\nours\nc\n",
+			theirs: "a\ntheirs\nc\n",
+			want: "a\n" +
+				conflictOurs + "ours\n" +
+				conflictBase + "b\n" +
+				conflictSep + "theirs\n" +
+				conflictTheirs + "c\n",
+			conflicts: 1,
+		},
+		{
+			name:      "多处冲突",
+			base:      "a\nb\nc\nd\ne\n",
+			ours:      "a\nB1\nc\nD1\ne\n",
+			theirs:    "a\nB2\nc\nD2\ne\n",
+			want:      "a\n" + conflictOurs + "B1\n" + conflictBase + "b\n" + conflictSep + "B2\n" + conflictTheirs + "c\n" + conflictOurs + "D1\n" + conflictBase + "d\n" + conflictSep + "D2\n" + conflictTheirs + "e\n",
+			conflicts: 2,
+		},
+		{
+			name:   "当前在开头插入",
+			base:   "a\nb\n",
+			ours:   "head\na\nb\n",
+			theirs: "a\nB\n",
+			want:   "head\na\nB\n",
+		},
+		{
+			name:   "新版在末尾追加",
+			base:   "a\nb\n",
+			ours:   "A\nb\n",
+			theirs: "a\nb\ntail\n",
+			want:   "A\nb\ntail\n",
+		},
+		{
+			name:   "双方在开头及末尾插入",
+			base:   "a\nb\n",
+			ours:   "head\na\nb\n",
+			theirs: "a\nb\ntail\n",
+			want:   "head\na\nb\ntail\n",
+		},
+		{
+			name:   "双方在末尾插入不同内容",
+			base:   "a\n",
+			ours:   "a\nours\n",
+			theirs: "a\ntheirs\n",
+			want: "a\n" +
+				conflictOurs + "ours\n" +
+				conflictBase +
+				conflictSep + "theirs\n" +
+				conflictTheirs,
+			conflicts: 1,
+		},
+		{
+			name:   "缺少末尾换行",
+			base:   "a\nb",
+			ours:   "a\nb",
+			theirs: "a\nB",
+			want:   "a\nB\n",
+		},
+		{
+			name:   "冲突内容缺少末尾换行",
+			base:   "a\nb",
+			ours:   "a\nours",
+			theirs: "a\ntheirs",
+			want: "a\n" +
+				conflictOurs + "ours\n" +
+				conflictBase + "b\n" +
+				conflictSep + "theirs\n" +
+				conflictTheirs,
+			conflicts: 1,
+		},
+		{
+			name:   "原始模板为空且当前未修改",
+			base:   "",
+			ours:   "",
+			theirs: "a\nb\n",
+			want:   "a\nb\n",
+		},
+		{
+			name:   "原始模板为空且双方内容相同",
+			base:   "",
+			ours:   "a\n",
+			theirs: "a\n",
+			want:   "a\n",
+		},
+		{
+			name:   "原始模板为空且双方内容不同",
+			base:   "",
+			ours:   "ours\n",
+			theirs: "theirs\n",
+			want: conflictOurs + "ours\n" +
+				conflictBase +
+				conflictSep + "theirs\n" +
+				conflictTheirs,
+			conflicts: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, conflicts := Merge(tt.base, tt.ours, tt.theirs)
+			if got != tt.want {
+				t.Errorf("Merge() = %q, want %q", got, tt.want)
+			}
+			if conflicts != tt.conflicts {
+				t.Errorf("Merge() conflicts = %v, want %v", conflicts, tt.conflicts)
+			}
+		})
+	}
+}
+
+// TestMatchLines function    测试计算最长公共子序列的行对应关系.
+func TestMatchLines(t *testing.T) {
+	tests := []struct {
+		name string
+		o    []string
+		a    []string
+		want []int
+	}{
+		{
+			name: "完全相同",
+			o:    []string{"a", "b"},
+			a:    []string{"a", "b"},
+			want: []int{0, 1},
+		},
+		{
+			name: "删除及插入",
+			o:    []string{"a", "b", "c"},
+			a:    []string{"x", "a", "c"},
+			want: []int{1, -1, 2},
+		},
+		{
+			name: "目标为空",
+			o:    []string{"a"},
+			a:    nil,
+			want: []int{-1},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := matchLines(tt.o, tt.a)
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("matchLines() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
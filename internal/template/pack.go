@@ -0,0 +1,236 @@
+package template
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/spelens-gud/gsus/internal/config"
+	"github.com/spelens-gud/gsus/internal/errors"
+	"github.com/spelens-gud/gsus/internal/utils"
+)
+
+// resolvePack function    从已安装的模板包中查找模板.
+func resolvePack(pack, name string) (Source, error) {
+	dir, err := config.PackDir(pack)
+	if err != nil {
+		return Source{}, err
+	}
+	m, err := config.LoadPackManifest(dir)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return Source{}, errors.New(errors.ErrCodeTemplate, fmt.Sprintf("模板包 %s 未安装，执行 gsus template pack install 安装", pack))
+		}
+		return Source{}, err
+	}
+	if !m.HasTemplate(name) {
+		return Source{}, errors.New(errors.ErrCodeTemplate, fmt.Sprintf("模板包 %s 不提供模板 %s，可选: %s", pack, name, strings.Join(m.Templates, ", ")))
+	}
+	path := filepath.Join(dir, filepath.FromSlash(name)+config.GsusTemplateSuffix)
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return Source{}, errors.WrapWithCode(err, errors.ErrCodeFile, fmt.Sprintf("读取模板 %s 失败: %s", path, err))
+	}
+	return Source{Name: name, Layer: LayerPack, Pack: pack, Path: path, Content: data}, nil
+}
+
+// packSources function    返回模板包中除主模板外的所有模板及公共片段.
+func packSources(pack, mainName string) (sources []Source, err error) {
+	dir, err := config.PackDir(pack)
+	if err != nil {
+		return nil, err
+	}
+	names, err := listDir(dir)
+	if err != nil {
+		return nil, err
+	}
+	for _, name := range names {
+		if name == mainName {
+			continue
+		}
+		path := filepath.Join(dir, filepath.FromSlash(name)+config.GsusTemplateSuffix)
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, errors.WrapWithCode(err, errors.ErrCodeFile, fmt.Sprintf("读取模板 %s 失败: %s", path, err))
+		}
+		sources = append(sources, Source{Name: name, Layer: LayerPack, Pack: pack, Path: path, Content: data})
+	}
+	return sources, nil
+}
+
+// InstallPack function    从本地目录或 .tar.gz 归档安装模板包到 .gsus/packs/包名 下，已安装的同名模板包会被替换.
+// 返回模板包清单及替换前的版本，首次安装时版本为空.
+func InstallPack(src string) (m config.PackManifest, previous string, err error) {
+	root := src
+	if info, err := os.Stat(src); err != nil {
+		return m, "", errors.WrapWithCode(err, errors.ErrCodeFile, fmt.Sprintf("读取模板包失败: %s", src))
+	} else if !info.IsDir() {
+		if !strings.HasSuffix(src, ".tar.gz") && !strings.HasSuffix(src, ".tgz") {
+			return m, "", errors.New(errors.ErrCodeTemplate, fmt.Sprintf("不支持的模板包格式: %s，仅支持目录及 .tar.gz/.tgz 归档", src))
+		}
+		tmp, err := os.MkdirTemp("", "gsus-pack-")
+		if err != nil {
+			return m, "", errors.WrapWithCode(err, errors.ErrCodeFile, fmt.Sprintf("创建临时目录失败: %s", err))
+		}
+		defer os.RemoveAll(tmp)
+		if err = extractTarGz(src, tmp); err != nil {
+			return m, "", err
+		}
+		if root, err = packRoot(tmp); err != nil {
+			return m, "", errors.WrapWithCode(err, errors.ErrCodeTemplate, fmt.Sprintf("%s: %s", src, err))
+		}
+	}
+
+	if m, err = config.LoadPackManifest(root); err != nil {
+		return m, "", err
+	}
+	names, err := listDir(root)
+	if err != nil {
+		return m, "", err
+	}
+	files := make(map[string][]byte, len(names))
+	for _, name := range names {
+		path := filepath.Join(root, filepath.FromSlash(name)+config.GsusTemplateSuffix)
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return m, "", errors.WrapWithCode(err, errors.ErrCodeFile, fmt.Sprintf("读取模板 %s 失败: %s", path, err))
+		}
+		if _, err = parseFile(path, data); err != nil {
+			return m, "", err
+		}
+		files[name] = data
+	}
+	for _, name := range m.Templates {
+		if _, ok := files[name]; !ok {
+			return m, "", errors.New(errors.ErrCodeTemplate, fmt.Sprintf("模板包 %s 缺少模板文件: %s%s", m.Name, name, config.GsusTemplateSuffix))
+		}
+	}
+
+	dir, err := config.PackDir(m.Name)
+	if err != nil {
+		return m, "", err
+	}
+	if installed, err := config.LoadPackManifest(dir); err == nil {
+		previous = installed.Version
+	}
+	if err = os.RemoveAll(dir); err != nil {
+		return m, previous, errors.WrapWithCode(err, errors.ErrCodeFile, fmt.Sprintf("删除已安装的模板包失败: %s", err))
+	}
+	manifest, err := os.ReadFile(filepath.Join(root, config.GsusPackManifest))
+	if err != nil {
+		return m, previous, errors.WrapWithCode(err, errors.ErrCodeFile, fmt.Sprintf("读取模板包清单失败: %s", err))
+	}
+	if err = writePackFile(filepath.Join(dir, config.GsusPackManifest), manifest); err != nil {
+		return m, previous, err
+	}
+	for _, name := range names {
+		if err = writePackFile(filepath.Join(dir, filepath.FromSlash(name)+config.GsusTemplateSuffix), files[name]); err != nil {
+			return m, previous, err
+		}
+	}
+	return m, previous, nil
+}
+
+// writePackFile function    写入模板包文件.
+func writePackFile(path string, data []byte) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0o775); err != nil {
+		return errors.WrapWithCode(err, errors.ErrCodeFile, fmt.Sprintf("创建模板包目录失败: %s", err))
+	}
+	if err := os.WriteFile(path, data, 0o664); err != nil {
+		return errors.WrapWithCode(err, errors.ErrCodeFile, fmt.Sprintf("写入模板包文件失败: %s", err))
+	}
+	return nil
+}
+
+// packRoot function    返回解压目录中清单文件所在的目录，支持归档内包含一层顶级目录.
+func packRoot(dir string) (string, error) {
+	if _, err := os.Stat(filepath.Join(dir, config.GsusPackManifest)); err == nil {
+		return dir, nil
+	}
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return "", err
+	}
+	if len(entries) == 1 && entries[0].IsDir() {
+		sub := filepath.Join(dir, entries[0].Name())
+		if _, err = os.Stat(filepath.Join(sub, config.GsusPackManifest)); err == nil {
+			return sub, nil
+		}
+	}
+	return "", errors.New(errors.ErrCodeTemplate, fmt.Sprintf("未找到模板包清单 %s", config.GsusPackManifest))
+}
+
+// extractTarGz function    将 .tar.gz 归档解压到指定目录，拒绝绝对路径及指向目录外的文件.
+func extractTarGz(src, dst string) error {
+	f, err := os.Open(src)
+	if err != nil {
+		return errors.WrapWithCode(err, errors.ErrCodeFile, fmt.Sprintf("读取模板包失败: %s", src))
+	}
+	defer f.Close()
+	gz, err := gzip.NewReader(f)
+	if err != nil {
+		return errors.WrapWithCode(err, errors.ErrCodeTemplate, fmt.Sprintf("解压模板包 %s 失败: %s", src, err))
+	}
+	defer gz.Close()
+
+	tr := tar.NewReader(gz)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return errors.WrapWithCode(err, errors.ErrCodeTemplate, fmt.Sprintf("解压模板包 %s 失败: %s", src, err))
+		}
+		path := filepath.Join(dst, filepath.FromSlash(hdr.Name))
+		if rel, err := filepath.Rel(dst, path); err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) ||
+			strings.HasPrefix(hdr.Name, "/") || filepath.IsAbs(hdr.Name) {
+			return errors.New(errors.ErrCodeTemplate, fmt.Sprintf("模板包 %s 包含非法路径: %s", src, hdr.Name))
+		}
+		switch hdr.Typeflag {
+		case tar.TypeDir:
+			if err = os.MkdirAll(path, 0o775); err != nil {
+				return errors.WrapWithCode(err, errors.ErrCodeFile, fmt.Sprintf("创建目录失败: %s", err))
+			}
+		case tar.TypeReg:
+			data, err := io.ReadAll(tr)
+			if err != nil {
+				return errors.WrapWithCode(err, errors.ErrCodeTemplate, fmt.Sprintf("解压模板包 %s 失败: %s", src, err))
+			}
+			if err = writePackFile(path, data); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// ListPacks function    返回已安装的模板包清单，按名称排序.
+func ListPacks() ([]config.PackManifest, error) {
+	dir := config.GsusPacksDir
+	if err := utils.FixFilepathByProjectDir(&dir); err != nil {
+		return nil, errors.WrapWithCode(err, errors.ErrCodeFile, fmt.Sprintf("无法解析模板包目录: %s", err))
+	}
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, errors.WrapWithCode(err, errors.ErrCodeFile, fmt.Sprintf("读取模板包目录失败: %s", err))
+	}
+	var packs []config.PackManifest
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			continue
+		}
+		m, err := config.LoadPackManifest(filepath.Join(dir, entry.Name()))
+		if err != nil {
+			return nil, err
+		}
+		packs = append(packs, m)
+	}
+	return packs, nil
+}
@@ -0,0 +1,215 @@
+package template
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/spelens-gud/gsus/internal/config"
+	"github.com/spelens-gud/gsus/internal/utils"
+)
+
+// TestExtractTarGz function    测试解压模板包归档时拒绝目录外的路径.
+func TestExtractTarGz(t *testing.T) {
+	tests := []struct {
+		name    string
+		entries map[string]string // 归档内的文件名 -> 内容
+		wantErr string
+	}{
+		{
+			name:    "合法路径",
+			entries: map[string]string{"pack.yaml": "name: a", "_partials/header.tmpl": "header"},
+		},
+		{
+			name:    "上级目录",
+			entries: map[string]string{"../evil.tmpl": "evil"},
+			wantErr: "包含非法路径: ../evil.tmpl",
+		},
+		{
+			name:    "子目录中的上级目录",
+			entries: map[string]string{"a/../../evil.tmpl": "evil"},
+			wantErr: "包含非法路径",
+		},
+		{
+			name:    "绝对路径",
+			entries: map[string]string{"/tmp/evil.tmpl": "evil"},
+			wantErr: "包含非法路径: /tmp/evil.tmpl",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			archive := writeTarGz(t, filepath.Join(dir, "pack.tar.gz"), tt.entries)
+			dst := filepath.Join(dir, "out")
+			err := extractTarGz(archive, dst)
+			if len(tt.wantErr) > 0 {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Errorf("extractTarGz() error = %v, want contains %q", err, tt.wantErr)
+				}
+				if _, err = os.Stat(filepath.Join(dir, "evil.tmpl")); err == nil {
+					t.Errorf("extractTarGz() wrote file outside destination")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("extractTarGz() error = %v", err)
+			}
+			for name, content := range tt.entries {
+				data, err := os.ReadFile(filepath.Join(dst, filepath.FromSlash(name)))
+				if err != nil || string(data) != content {
+					t.Errorf("extractTarGz() %s = %q, %v, want %q", name, data, err, content)
+				}
+			}
+		})
+	}
+}
+
+// TestInstallPack function    测试安装、替换模板包及清单校验.
+func TestInstallPack(t *testing.T) {
+	project := t.TempDir()
+	t.Setenv(utils.ProjectDirEnv, project)
+	src := t.TempDir()
+
+	// 归档内包含一层顶级目录
+	v1 := writeTarGz(t, filepath.Join(src, "platform-1.0.tar.gz"), map[string]string{
+		"platform-1.0/pack.yaml":               "name: platform\nversion: \"1.0\"\ntemplates: [dao, old]\n",
+		"platform-1.0/dao.tmpl":                `{{ template "header" . }}dao v1`,
+		"platform-1.0/old.tmpl":                "old",
+		"platform-1.0/_partials/header.tmpl":   `{{ define "header" }}// header{{ end }}`,
+		"platform-1.0/docs/ignored.md":         "ignored",
+		"platform-1.0/_partials/nested/x.tmpl": "x",
+	})
+	m, previous, err := InstallPack(v1)
+	if err != nil {
+		t.Fatalf("InstallPack(v1) error = %v", err)
+	}
+	if m.Name != "platform" || m.Version != "1.0" || previous != "" {
+		t.Errorf("InstallPack(v1) = %s %s, previous %q, want platform 1.0, previous empty", m.Name, m.Version, previous)
+	}
+	dir := filepath.Join(project, config.GsusPacksDir, "platform")
+	for _, name := range []string{"pack.yaml", "dao.tmpl", "old.tmpl", "_partials/header.tmpl", "_partials/nested/x.tmpl"} {
+		if _, err = os.Stat(filepath.Join(dir, filepath.FromSlash(name))); err != nil {
+			t.Errorf("InstallPack(v1) missing %s: %v", name, err)
+		}
+	}
+
+	// 从目录安装新版本，替换已安装的模板包
+	v2 := filepath.Join(src, "platform-2.0")
+	writeFiles(t, v2, map[string]string{
+		"pack.yaml": "name: platform\nversion: \"2.0\"\ntemplates: [dao]\n",
+		"dao.tmpl":  "dao v2",
+	})
+	m, previous, err = InstallPack(v2)
+	if err != nil {
+		t.Fatalf("InstallPack(v2) error = %v", err)
+	}
+	if m.Version != "2.0" || previous != "1.0" {
+		t.Errorf("InstallPack(v2) version = %s, previous %q, want 2.0, previous 1.0", m.Version, previous)
+	}
+	if data, _ := os.ReadFile(filepath.Join(dir, "dao.tmpl")); string(data) != "dao v2" {
+		t.Errorf("InstallPack(v2) dao.tmpl = %q, want %q", data, "dao v2")
+	}
+	for _, name := range []string{"old.tmpl", "_partials/header.tmpl"} {
+		if _, err = os.Stat(filepath.Join(dir, filepath.FromSlash(name))); !os.IsNotExist(err) {
+			t.Errorf("InstallPack(v2) %s should be removed, stat error = %v", name, err)
+		}
+	}
+
+	src2, err := Resolve("platform:dao")
+	if err != nil || string(src2.Content) != "dao v2" {
+		t.Errorf("Resolve(platform:dao) = %q, %v, want %q", src2.Content, err, "dao v2")
+	}
+}
+
+// TestInstallPack_Invalid function    测试安装不合法的模板包.
+func TestInstallPack_Invalid(t *testing.T) {
+	tests := []struct {
+		name    string
+		files   map[string]string
+		wantErr string
+	}{
+		{
+			name:    "缺少清单",
+			files:   map[string]string{"dao.tmpl": "dao"},
+			wantErr: "读取模板包清单失败",
+		},
+		{
+			name:    "缺少模板文件",
+			files:   map[string]string{"pack.yaml": "name: platform\nversion: \"1.0\"\ntemplates: [dao, service]\n", "dao.tmpl": "dao"},
+			wantErr: "模板包 platform 缺少模板文件: service.tmpl",
+		},
+		{
+			name:    "模板语法错误",
+			files:   map[string]string{"pack.yaml": "name: platform\nversion: \"1.0\"\ntemplates: [dao]\n", "dao.tmpl": "{{ if }}"},
+			wantErr: "解析模板",
+		},
+		{
+			name:    "模板名称包含上级目录",
+			files:   map[string]string{"pack.yaml": "name: platform\nversion: \"1.0\"\ntemplates: [../dao]\n", "dao.tmpl": "dao"},
+			wantErr: "模板名称不合法",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			project := t.TempDir()
+			t.Setenv(utils.ProjectDirEnv, project)
+			src := filepath.Join(t.TempDir(), "pack")
+			writeFiles(t, src, tt.files)
+
+			_, _, err := InstallPack(src)
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("InstallPack() error = %v, want contains %q", err, tt.wantErr)
+			}
+			if _, err = os.Stat(filepath.Join(project, config.GsusPacksDir)); !os.IsNotExist(err) {
+				t.Errorf("InstallPack() should not write packs dir, stat error = %v", err)
+			}
+		})
+	}
+}
+
+// writeTarGz function    将文件写入 .tar.gz 归档并返回归档路径.
+func writeTarGz(t *testing.T, path string, entries map[string]string) string {
+	t.Helper()
+	var bf bytes.Buffer
+	gz := gzip.NewWriter(&bf)
+	tw := tar.NewWriter(gz)
+	for _, name := range sortedKeys(entries) {
+		content := entries[name]
+		if err := tw.WriteHeader(&tar.Header{Name: name, Mode: 0o644, Size: int64(len(content)), Typeflag: tar.TypeReg}); err != nil {
+			t.Fatalf("WriteHeader(%s) error = %v", name, err)
+		}
+		if _, err := tw.Write([]byte(content)); err != nil {
+			t.Fatalf("Write(%s) error = %v", name, err)
+		}
+	}
+	if err := tw.Close(); err != nil {
+		t.Fatalf("tar Close() error = %v", err)
+	}
+	if err := gz.Close(); err != nil {
+		t.Fatalf("gzip Close() error = %v", err)
+	}
+	if err := os.WriteFile(path, bf.Bytes(), 0o644); err != nil {
+		t.Fatalf("WriteFile(%s) error = %v", path, err)
+	}
+	return path
+}
+
+// writeFiles function    在目录下写入文件.
+func writeFiles(t *testing.T, dir string, files map[string]string) {
+	t.Helper()
+	for name, content := range files {
+		path := filepath.Join(dir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatalf("MkdirAll(%s) error = %v", path, err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatalf("WriteFile(%s) error = %v", path, err)
+		}
+	}
+}
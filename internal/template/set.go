@@ -0,0 +1,291 @@
+package template
+
+import (
+	"crypto/md5"
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"text/template"
+	"text/template/parse"
+
+	"github.com/spelens-gud/gsus/internal/config"
+	"github.com/spelens-gud/gsus/internal/errors"
+	"github.com/spelens-gud/gsus/internal/logger"
+	"github.com/spelens-gud/gsus/internal/utils"
+)
+
+// templateFile struct    模板集合中的单个模板文件.
+type templateFile struct {
+	path string             // 模板文件路径
+	data []byte             // 模板内容
+	tmpl *template.Template // 单独解析的模板
+}
+
+// definition struct    模板集合中的命名模板定义.
+type definition struct {
+	file  *templateFile
+	tree  *parse.Tree
+	block bool // 是否由 block 定义，block 可被其他文件中的 define 覆盖
+}
+
+// parseSet function    将主模板与其引用的模板及 _partials 公共片段解析为同一模板集合，模板包模板仅使用所属模板包中的模板.
+// 每个文件以去除后缀的文件名命名，文件之间可通过 {{ template "name" . }} 互相引用，
+// 同名定义出现在多个文件中时返回错误，block 定义可被一个 define 覆盖，覆盖的 define 需位于公共片段或被引用的模板中.
+// 返回主模板及包含集合中所有模板内容的哈希.
+func parseSet(src Source) (*template.Template, string, error) {
+	main, err := parseFile(src.Path, src.Content)
+	if err != nil {
+		return nil, "", err
+	}
+	var candidates []Source
+	if len(src.Pack) > 0 {
+		candidates, err = packSources(src.Pack, src.Name)
+	} else {
+		candidates, err = sharedSources(src.Name)
+	}
+	if err != nil {
+		return nil, "", err
+	}
+	files, err := selectFiles(main, candidates)
+	if err != nil {
+		return nil, "", err
+	}
+	set, err := mergeFiles(main, files)
+	if err != nil {
+		return nil, "", err
+	}
+
+	hash := md5.New()
+	for _, f := range files {
+		hash.Write(f.data)
+	}
+	return set, fmt.Sprintf("%x", hash.Sum(nil)), nil
+}
+
+// selectFiles function    从候选模板中选出主模板所需的文件，公共片段总是加入集合，其他模板仅在被引用时加入.
+// 按文件名引用的模板解析失败时返回错误，按 define 名称查找时跳过无法解析的模板并警告，
+// 避免无关模板的语法错误影响其他模板.
+func selectFiles(main *templateFile, candidates []Source) ([]*templateFile, error) {
+	files := []*templateFile{main}
+	var pending []Source
+	for _, src := range candidates {
+		if !strings.HasPrefix(src.Name, config.GsusTemplatePartialsDir+"/") {
+			pending = append(pending, src)
+			continue
+		}
+		f, err := parseFile(src.Path, src.Content)
+		if err != nil {
+			return nil, err
+		}
+		files = append(files, f)
+	}
+
+	for len(pending) > 0 {
+		missing := undefinedRefs(main, files)
+		if len(missing) == 0 {
+			break
+		}
+		var rest []Source
+		added := false
+		for _, src := range pending {
+			if !missing[src.Name] {
+				rest = append(rest, src)
+				continue
+			}
+			f, err := parseFile(src.Path, src.Content)
+			if err != nil {
+				return nil, err
+			}
+			files, added = append(files, f), true
+		}
+		pending = rest
+		if added {
+			continue
+		}
+
+		// 引用的名称不是模板文件名时，从其他模板的 define 中查找
+		rest = nil
+		for _, src := range pending {
+			f, err := parseFile(src.Path, src.Content)
+			if err != nil {
+				logger.Warn("跳过无法解析的模板 %s: %s", displayTemplatePath(src.Path), err)
+				continue
+			}
+			if definesAny(main, f, missing) {
+				files, added = append(files, f), true
+			} else {
+				rest = append(rest, src)
+			}
+		}
+		pending = rest
+		if !added {
+			break
+		}
+	}
+	return files, nil
+}
+
+// mergeFiles function    检查各文件中的同名定义并合并到主模板.
+func mergeFiles(main *templateFile, files []*templateFile) (*template.Template, error) {
+	// 收集所有命名模板定义
+	defs := make(map[string][]definition)
+	var names []string
+	for _, f := range files {
+		for _, t := range f.tmpl.Templates() {
+			if !isDefinition(main, f, t) {
+				continue
+			}
+			if _, ok := defs[t.Name()]; !ok {
+				names = append(names, t.Name())
+			}
+			defs[t.Name()] = append(defs[t.Name()], definition{
+				file:  f,
+				tree:  t.Tree,
+				block: t.Name() != f.tmpl.Name() && isBlock(f.data, t.Name()),
+			})
+		}
+	}
+
+	// 检查重名并合并到主模板
+	set := main.tmpl
+	sort.Strings(names)
+	for _, name := range names {
+		def, err := resolveDefinition(name, defs[name])
+		if err != nil {
+			return nil, err
+		}
+		if def.file == main && set.Lookup(name) != nil && set.Lookup(name).Tree == def.tree {
+			continue
+		}
+		if _, err = set.AddParseTree(name, def.tree); err != nil {
+			return nil, errors.WrapWithCode(err, errors.ErrCodeTemplate, fmt.Sprintf("合并模板 %s 失败: %s", name, err))
+		}
+	}
+	return set, nil
+}
+
+// isDefinition function    判断是否为有效的命名模板定义，仅包含 define 的文件本身不作为定义.
+func isDefinition(main, f *templateFile, t *template.Template) bool {
+	if t.Tree == nil {
+		return false
+	}
+	return t.Name() != f.tmpl.Name() || f == main || !parse.IsEmptyTree(t.Tree.Root)
+}
+
+// definesAny function    判断模板文件是否定义了任一指定名称.
+func definesAny(main, f *templateFile, names map[string]bool) bool {
+	for _, t := range f.tmpl.Templates() {
+		if names[t.Name()] && isDefinition(main, f, t) {
+			return true
+		}
+	}
+	return false
+}
+
+// undefinedRefs function    返回模板文件中通过 template/block 引用但尚未定义的名称.
+func undefinedRefs(main *templateFile, files []*templateFile) map[string]bool {
+	refs := make(map[string]bool)
+	defined := make(map[string]bool)
+	for _, f := range files {
+		for _, t := range f.tmpl.Templates() {
+			if !isDefinition(main, f, t) {
+				continue
+			}
+			defined[t.Name()] = true
+			collectRefs(t.Tree.Root, refs)
+		}
+	}
+	for name := range defined {
+		delete(refs, name)
+	}
+	return refs
+}
+
+// collectRefs function    收集语法树中 template/block 引用的模板名称.
+func collectRefs(node parse.Node, refs map[string]bool) {
+	switch n := node.(type) {
+	case *parse.ListNode:
+		if n == nil {
+			return
+		}
+		for _, child := range n.Nodes {
+			collectRefs(child, refs)
+		}
+	case *parse.TemplateNode:
+		refs[n.Name] = true
+	case *parse.IfNode:
+		collectRefs(n.List, refs)
+		collectRefs(n.ElseList, refs)
+	case *parse.RangeNode:
+		collectRefs(n.List, refs)
+		collectRefs(n.ElseList, refs)
+	case *parse.WithNode:
+		collectRefs(n.List, refs)
+		collectRefs(n.ElseList, refs)
+	}
+}
+
+// resolveDefinition function    从同名定义中选出生效的定义，多个 define 或多个 block 同名时返回错误.
+func resolveDefinition(name string, defs []definition) (definition, error) {
+	if len(defs) == 1 {
+		return defs[0], nil
+	}
+	var overrides []definition
+	for _, def := range defs {
+		if !def.block {
+			overrides = append(overrides, def)
+		}
+	}
+	if len(overrides) == 1 {
+		return overrides[0], nil
+	}
+
+	paths := make([]string, 0, len(defs))
+	for _, def := range defs {
+		paths = append(paths, displayTemplatePath(def.file.path))
+	}
+	return definition{}, errors.New(errors.ErrCodeTemplate, fmt.Sprintf("模板 %s 重复定义: %s", name, strings.Join(paths, ", ")))
+}
+
+// sharedSources function    返回各层级中除主模板外的所有模板及公共片段，同名模板仅使用优先级最高的一个.
+func sharedSources(mainName string) (sources []Source, err error) {
+	all, err := List()
+	if err != nil {
+		return nil, err
+	}
+	for _, src := range all {
+		if src.Name != mainName {
+			sources = append(sources, src)
+		}
+	}
+	return sources, nil
+}
+
+// parseFile function    单独解析模板文件，模板以去除后缀的文件名命名.
+func parseFile(path string, data []byte) (*templateFile, error) {
+	name := strings.TrimSuffix(filepath.Base(path), config.GsusTemplateSuffix)
+	tmpl, err := utils.NewTemplate(name).Parse(string(data))
+	if err != nil {
+		return nil, errors.WrapWithCode(err, errors.ErrCodeTemplate, fmt.Sprintf("解析模板 %s 失败: %s", displayTemplatePath(path), err))
+	}
+	return &templateFile{path: path, data: data, tmpl: tmpl}, nil
+}
+
+// displayTemplatePath function    返回相对于项目根目录的模板路径，用于错误信息.
+func displayTemplatePath(path string) string {
+	dir, err := utils.GetProjectDir()
+	if err != nil {
+		return path
+	}
+	if rel, err := filepath.Rel(dir, path); err == nil && !strings.HasPrefix(rel, "..") {
+		return rel
+	}
+	return path
+}
+
+// isBlock function    判断模板文件中的命名模板是否由 block 定义.
+func isBlock(data []byte, name string) bool {
+	return regexp.MustCompile(`\{\{-?\s*block\s+"` + regexp.QuoteMeta(name) + `"`).Match(data)
+}
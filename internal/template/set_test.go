@@ -0,0 +1,204 @@
+package template
+
+import (
+	"sort"
+	"strings"
+	"testing"
+)
+
+// TestMergeFiles function    测试模板集合中的重名检查及 block 覆盖.
+func TestMergeFiles(t *testing.T) {
+	tests := []struct {
+		name    string
+		main    string
+		files   map[string]string // 文件名 -> 模板内容
+		want    string
+		wantErr string
+	}{
+		{
+			name:  "引用公共片段",
+			main:  `{{ template "header" . }}body`,
+			files: map[string]string{"_partials/header": `{{ define "header" }}head {{ end }}`},
+			want:  "head body",
+		},
+		{
+			name:  "按文件名引用",
+			main:  `{{ template "header" . }}body`,
+			files: map[string]string{"header": `head `},
+			want:  "head body",
+		},
+		{
+			name:  "block 默认内容",
+			main:  `{{ block "body" . }}default{{ end }}`,
+			files: map[string]string{"_partials/other": `{{ define "other" }}other{{ end }}`},
+			want:  "default",
+		},
+		{
+			name:  "define 覆盖 block",
+			main:  `{{ block "body" . }}default{{ end }}`,
+			files: map[string]string{"_partials/body": `{{ define "body" }}override{{ end }}`},
+			want:  "override",
+		},
+		{
+			name:  "define 覆盖其他文件中的 block",
+			main:  `{{ template "layout" . }}`,
+			files: map[string]string{"_partials/layout": `[{{ block "body" . }}default{{ end }}]`, "_partials/body": `{{ define "body" }}override{{ end }}`},
+			want:  "[override]",
+		},
+		{
+			name:    "重复 define",
+			main:    `{{ template "header" . }}`,
+			files:   map[string]string{"_partials/a": `{{ define "header" }}a{{ end }}`, "_partials/b": `{{ define "header" }}b{{ end }}`},
+			wantErr: "模板 header 重复定义",
+		},
+		{
+			name:    "主模板与公共片段重复 define",
+			main:    `{{ define "header" }}main{{ end }}{{ template "header" . }}`,
+			files:   map[string]string{"_partials/a": `{{ define "header" }}a{{ end }}`},
+			wantErr: "模板 header 重复定义",
+		},
+		{
+			name:    "重复 block",
+			main:    `{{ block "body" . }}main{{ end }}`,
+			files:   map[string]string{"_partials/a": `{{ block "body" . }}a{{ end }}`},
+			wantErr: "模板 body 重复定义",
+		},
+		{
+			name:    "多个 define 覆盖同一 block",
+			main:    `{{ block "body" . }}default{{ end }}`,
+			files:   map[string]string{"_partials/a": `{{ define "body" }}a{{ end }}`, "_partials/b": `{{ define "body" }}b{{ end }}`},
+			wantErr: "模板 body 重复定义",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			main := mustParseFile(t, "main", tt.main)
+			files := []*templateFile{main}
+			for _, name := range sortedKeys(tt.files) {
+				files = append(files, mustParseFile(t, name, tt.files[name]))
+			}
+
+			set, err := mergeFiles(main, files)
+			if len(tt.wantErr) > 0 {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Errorf("mergeFiles() error = %v, want contains %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("mergeFiles() error = %v", err)
+			}
+			var bf strings.Builder
+			if err = set.Execute(&bf, nil); err != nil {
+				t.Fatalf("Execute() error = %v", err)
+			}
+			if bf.String() != tt.want {
+				t.Errorf("Execute() = %q, want %q", bf.String(), tt.want)
+			}
+		})
+	}
+}
+
+// TestSelectFiles function    测试仅加入主模板引用的模板及公共片段.
+func TestSelectFiles(t *testing.T) {
+	tests := []struct {
+		name    string
+		main    string
+		sources map[string]string // 模板名称 -> 模板内容
+		want    []string          // 加入集合的模板文件名，不含主模板
+		wantErr string
+	}{
+		{
+			name:    "公共片段总是加入",
+			main:    `body`,
+			sources: map[string]string{"_partials/a": `{{ define "a" }}a{{ end }}`, "other": `other`},
+			want:    []string{"_partials/a"},
+		},
+		{
+			name:    "跳过未引用的语法错误模板",
+			main:    `{{ template "header" . }}`,
+			sources: map[string]string{"header": `head`, "broken": `{{ if }}`},
+			want:    []string{"header"},
+		},
+		{
+			name:    "按文件名递归引用",
+			main:    `{{ template "a" . }}`,
+			sources: map[string]string{"a": `{{ template "b" . }}`, "b": `b`, "c": `c`},
+			want:    []string{"a", "b"},
+		},
+		{
+			name:    "按 define 名称查找并跳过语法错误模板",
+			main:    `{{ template "header" . }}`,
+			sources: map[string]string{"common": `{{ define "header" }}head{{ end }}`, "broken": `{{ if }}`, "other": `other`},
+			want:    []string{"common"},
+		},
+		{
+			name:    "条件分支中的引用",
+			main:    `{{ if . }}{{ template "a" . }}{{ else }}{{ range . }}{{ template "b" . }}{{ end }}{{ end }}`,
+			sources: map[string]string{"a": `a`, "b": `b`},
+			want:    []string{"a", "b"},
+		},
+		{
+			name:    "引用的模板语法错误",
+			main:    `{{ template "broken" . }}`,
+			sources: map[string]string{"broken": `{{ if }}`},
+			wantErr: "解析模板",
+		},
+		{
+			name:    "公共片段语法错误",
+			main:    `body`,
+			sources: map[string]string{"_partials/broken": `{{ if }}`},
+			wantErr: "解析模板",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			main := mustParseFile(t, "main", tt.main)
+			var candidates []Source
+			for _, name := range sortedKeys(tt.sources) {
+				candidates = append(candidates, Source{Name: name, Path: "/templates/" + name + ".tmpl", Content: []byte(tt.sources[name])})
+			}
+
+			files, err := selectFiles(main, candidates)
+			if len(tt.wantErr) > 0 {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Errorf("selectFiles() error = %v, want contains %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("selectFiles() error = %v", err)
+			}
+			var got []string
+			for _, f := range files[1:] {
+				got = append(got, strings.TrimSuffix(strings.TrimPrefix(f.path, "/templates/"), ".tmpl"))
+			}
+			sort.Strings(got)
+			if strings.Join(got, ",") != strings.Join(tt.want, ",") {
+				t.Errorf("selectFiles() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+// mustParseFile function    解析测试模板文件.
+func mustParseFile(t *testing.T, name, content string) *templateFile {
+	t.Helper()
+	f, err := parseFile("/templates/"+name+".tmpl", []byte(content))
+	if err != nil {
+		t.Fatalf("parseFile(%s) error = %v", name, err)
+	}
+	return f
+}
+
+// sortedKeys function    按顺序返回映射的键.
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
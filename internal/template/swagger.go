@@ -0,0 +1,10 @@
+package template
+
+const DefaultSwaggerMainTemplate = `// Package {{ .Package }} 接口文档头部信息，由 gsus http swagger 读取.
+//
+// @title {{ .Title }}
+// @version 1.0
+// @description {{ .Title }} 接口文档
+// @BasePath /
+package {{ .Package }}
+`
@@ -30,7 +30,7 @@ func Execute(f func() (err error)) {
 		if err != nil {
 			var e *exec.ExitError
 			switch {
-			case errors.As(err, e):
+			case errors.As(err, &e):
 				logger.Fatal("%v: %s", err, e.Stderr)
 			default:
 				logger.Fatal("%+v", err)
@@ -57,14 +57,23 @@ func ExecFiles(scope string, f func(path string) (err error)) (err error) {
 
 	// walk files
 	wg := new(errgroup.Group)
-	_ = filepath.Walk(scope, func(path string, info os.FileInfo, _ error) (_ error) {
+	walkErr := filepath.Walk(scope, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
 		if info.IsDir() || strings.HasSuffix(path, "_test.go") || !strings.HasSuffix(path, ".go") {
-			return
+			return nil
 		}
 		wg.Go(func() error {
 			return f(path)
 		})
-		return
+		return nil
 	})
-	return wg.Wait()
+	if err = wg.Wait(); err != nil {
+		return err
+	}
+	if walkErr != nil {
+		return errors.WrapWithCode(walkErr, errors.ErrCodeFile, fmt.Sprintf("遍历文件失败: %s", walkErr))
+	}
+	return nil
 }
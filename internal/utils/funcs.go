@@ -0,0 +1,205 @@
+package utils
+
+import (
+	"fmt"
+	"path"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+	"text/template"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/spelens-gud/gsus/internal/errors"
+	"github.com/stoewer/go-strcase"
+)
+
+// TemplateFunc struct    模板函数定义.
+type TemplateFunc struct {
+	Name  string // 函数名
+	Usage string // 用法示例
+	Desc  string // 函数说明
+	Fn    any    // 函数实现
+}
+
+// templateFuncs 所有 gsus 模板可用的函数，按分类排列.
+var templateFuncs = []TemplateFunc{
+	// 命名转换
+	{Name: "snake", Usage: `{{ snake "UserInfo" }} => user_info`, Desc: "转换为蛇形命名", Fn: strcase.SnakeCase},
+	{Name: "camel", Usage: `{{ camel "user_info" }} => userInfo`, Desc: "转换为小驼峰命名", Fn: strcase.LowerCamelCase},
+	{Name: "pascal", Usage: `{{ pascal "user_info" }} => UserInfo`, Desc: "转换为大驼峰命名", Fn: strcase.UpperCamelCase},
+	{Name: "kebab", Usage: `{{ kebab "UserInfo" }} => user-info`, Desc: "转换为短横线命名", Fn: strcase.KebabCase},
+	{Name: "plural", Usage: `{{ plural "category" }} => categories`, Desc: "转换为英文复数形式", Fn: Plural},
+	{Name: "singular", Usage: `{{ singular "categories" }} => category`, Desc: "转换为英文单数形式", Fn: Singular},
+	{Name: "lowerFirst", Usage: `{{ lowerFirst "UserInfo" }} => userInfo`, Desc: "首字母小写，开头的缩写整体小写", Fn: lowerFirst},
+	{Name: "upperFirst", Usage: `{{ upperFirst "userInfo" }} => UserInfo`, Desc: "首字母大写", Fn: upperFirst},
+	{Name: "lower", Usage: `{{ lower "User" }} => user`, Desc: "转换为小写", Fn: strings.ToLower},
+	{Name: "upper", Usage: `{{ upper "id" }} => ID`, Desc: "转换为大写", Fn: strings.ToUpper},
+
+	// 字符串处理
+	{Name: "trimPrefix", Usage: `{{ .Name | trimPrefix "Get" }}`, Desc: "去除前缀", Fn: func(prefix, s string) string { return strings.TrimPrefix(s, prefix) }},
+	{Name: "trimSuffix", Usage: `{{ .Name | trimSuffix "Service" }}`, Desc: "去除后缀", Fn: func(suffix, s string) string { return strings.TrimSuffix(s, suffix) }},
+	{Name: "replace", Usage: `{{ .Name | replace "_" "-" }}`, Desc: "替换所有匹配的子串", Fn: func(old, new, s string) string { return strings.ReplaceAll(s, old, new) }},
+	{Name: "contains", Usage: `{{ if contains "Id" .Name }}...{{ end }}`, Desc: "判断是否包含子串", Fn: func(substr, s string) bool { return strings.Contains(s, substr) }},
+	{Name: "hasPrefix", Usage: `{{ if hasPrefix "Get" .Name }}...{{ end }}`, Desc: "判断是否以前缀开头", Fn: func(prefix, s string) bool { return strings.HasPrefix(s, prefix) }},
+	{Name: "hasSuffix", Usage: `{{ if hasSuffix "ID" .Name }}...{{ end }}`, Desc: "判断是否以后缀结尾", Fn: func(suffix, s string) bool { return strings.HasSuffix(s, suffix) }},
+	{Name: "join", Usage: `{{ join ", " .Names }}`, Desc: "使用分隔符拼接列表", Fn: join},
+	{Name: "quote", Usage: `{{ quote .Comment }} => "..."`, Desc: "转换为 Go 字符串字面量", Fn: func(v any) string { return strconv.Quote(fmt.Sprint(v)) }},
+	{Name: "indent", Usage: `{{ indent 4 .Body }}`, Desc: "为每个非空行添加指定数量的空格缩进", Fn: indent},
+
+	// 数据结构
+	{Name: "default", Usage: `{{ .Desc | default "暂无描述" }}`, Desc: "值为空时使用默认值", Fn: defaultValue},
+	{Name: "dict", Usage: `{{ template "field" dict "Name" .Name "Type" .Type }}`, Desc: "按键值对创建映射，常用于向子模板传递多个参数", Fn: dict},
+	{Name: "list", Usage: `{{ join "," (list "a" "b") }}`, Desc: "创建列表", Fn: func(items ...any) []any { return items }},
+
+	// Go 代码
+	{Name: "goType", Usage: `{{ goType "sql.NullString" }} => string`, Desc: "将数据库可空类型转换为对应的 Go 基础类型，其他类型原样返回", Fn: GoType},
+	{Name: "importAlias", Usage: `{{ importAlias "github.com/stoewer/go-strcase" }} => strcase`, Desc: "返回导入路径默认的包名", Fn: ImportAlias},
+}
+
+// TemplateFuncs function    返回所有模板函数定义，用于生成文档.
+func TemplateFuncs() []TemplateFunc {
+	return templateFuncs
+}
+
+// FuncMap function    返回所有 gsus 模板共用的函数映射.
+func FuncMap() template.FuncMap {
+	funcMap := make(template.FuncMap, len(templateFuncs))
+	for _, f := range templateFuncs {
+		funcMap[f.Name] = f.Fn
+	}
+	return funcMap
+}
+
+// NewTemplate function    创建注册了模板函数的模板，映射中不存在的键视为执行错误而非输出 <no value>.
+func NewTemplate(name string) *template.Template {
+	return template.New(name).Funcs(FuncMap()).Option("missingkey=error")
+}
+
+// lowerFirst function    首字母小写，开头的连续大写缩写整体小写，如 HTTPServer 返回 httpServer.
+func lowerFirst(s string) string {
+	runes := []rune(s)
+	for i := range runes {
+		// 缩写后紧跟小写字母时，缩写的最后一个字母属于下一个单词
+		if !unicode.IsUpper(runes[i]) || i > 0 && i+1 < len(runes) && unicode.IsLower(runes[i+1]) {
+			break
+		}
+		runes[i] = unicode.ToLower(runes[i])
+	}
+	return string(runes)
+}
+
+// upperFirst function    首字母大写.
+func upperFirst(s string) string {
+	if len(s) == 0 {
+		return s
+	}
+	r, size := utf8.DecodeRuneInString(s)
+	return string(unicode.ToUpper(r)) + s[size:]
+}
+
+// join function    使用分隔符拼接任意类型的列表.
+func join(sep string, list any) string {
+	v := reflect.ValueOf(list)
+	if v.Kind() != reflect.Slice && v.Kind() != reflect.Array {
+		return fmt.Sprint(list)
+	}
+	items := make([]string, 0, v.Len())
+	for i := 0; i < v.Len(); i++ {
+		items = append(items, fmt.Sprint(v.Index(i).Interface()))
+	}
+	return strings.Join(items, sep)
+}
+
+// indent function    为每个非空行添加缩进.
+func indent(spaces int, s string) string {
+	pad := strings.Repeat(" ", spaces)
+	lines := strings.Split(s, "\n")
+	for i, line := range lines {
+		if len(strings.TrimSpace(line)) > 0 {
+			lines[i] = pad + line
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// defaultValue function    值为空时返回默认值.
+func defaultValue(def, v any) any {
+	if v == nil {
+		return def
+	}
+	if rv := reflect.ValueOf(v); rv.IsZero() || (rv.Kind() == reflect.Slice || rv.Kind() == reflect.Map) && rv.Len() == 0 {
+		return def
+	}
+	return v
+}
+
+// dict function    按键值对创建映射.
+func dict(pairs ...any) (map[string]any, error) {
+	if len(pairs)%2 != 0 {
+		return nil, errors.New(errors.ErrCodeTemplate, "dict 参数必须为键值对")
+	}
+	m := make(map[string]any, len(pairs)/2)
+	for i := 0; i < len(pairs); i += 2 {
+		key, ok := pairs[i].(string)
+		if !ok {
+			return nil, errors.New(errors.ErrCodeTemplate, fmt.Sprintf("dict 的键必须为字符串: %v", pairs[i]))
+		}
+		m[key] = pairs[i+1]
+	}
+	return m, nil
+}
+
+// nullTypes 数据库可空类型对应的 Go 基础类型.
+var nullTypes = map[string]string{
+	"sql.NullString":  "string",
+	"sql.NullInt64":   "int64",
+	"sql.NullInt32":   "int32",
+	"sql.NullInt16":   "int16",
+	"sql.NullByte":    "byte",
+	"sql.NullFloat64": "float64",
+	"sql.NullBool":    "bool",
+	"sql.NullTime":    "time.Time",
+	"gorm.DeletedAt":  "time.Time",
+}
+
+// GoType function    将数据库可空类型转换为对应的 Go 基础类型，如 sql.NullString 转换为 string，其他类型原样返回.
+func GoType(typ string) string {
+	typ = strings.TrimSpace(typ)
+	if t, ok := nullTypes[typ]; ok {
+		return t
+	}
+	// sql.Null[T] 泛型
+	if strings.HasPrefix(typ, "sql.Null[") && strings.HasSuffix(typ, "]") {
+		return strings.TrimSuffix(strings.TrimPrefix(typ, "sql.Null["), "]")
+	}
+	return typ
+}
+
+var (
+	// majorVersionRegexp 匹配导入路径末尾的主版本号.
+	majorVersionRegexp = regexp.MustCompile(`^v[0-9]+$`)
+	// gopkgVersionRegexp 匹配 gopkg.in 导入路径的版本后缀，如 yaml.v3.
+	gopkgVersionRegexp = regexp.MustCompile(`\.v[0-9]+$`)
+	// identInvalidRegexp 匹配包名中的非法字符.
+	identInvalidRegexp = regexp.MustCompile(`[^a-zA-Z0-9_]+`)
+)
+
+// ImportAlias function    返回导入路径默认的包名.
+// 忽略末尾的主版本号、gopkg.in 版本后缀及 go- 前缀、-go 后缀，非法字符替换为下划线，如 github.com/stoewer/go-strcase 返回 strcase.
+func ImportAlias(importPath string) string {
+	importPath = strings.Trim(strings.TrimSpace(importPath), `"/`)
+	name := path.Base(importPath)
+	if majorVersionRegexp.MatchString(name) && strings.Contains(importPath, "/") {
+		name = path.Base(path.Dir(importPath))
+	}
+	name = gopkgVersionRegexp.ReplaceAllString(name, "")
+	name = strings.TrimPrefix(name, "go-")
+	name = strings.TrimSuffix(strings.TrimSuffix(name, "-go"), ".go")
+	name = strings.ToLower(identInvalidRegexp.ReplaceAllString(name, "_"))
+	if len(name) > 0 && unicode.IsDigit(rune(name[0])) {
+		name = "_" + name
+	}
+	return name
+}
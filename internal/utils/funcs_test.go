@@ -0,0 +1,112 @@
+package utils
+
+import (
+	"strings"
+	"testing"
+)
+
+// TestFuncMap_Case function    测试模板中的命名转换函数.
+func TestFuncMap_Case(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+		want string
+	}{
+		{name: "蛇形命名", text: `{{ snake "UserInfo" }}`, want: "user_info"},
+		{name: "蛇形命名缩写", text: `{{ snake "UserID" }}`, want: "user_id"},
+		{name: "蛇形命名开头缩写", text: `{{ snake "HTTPServer" }}`, want: "http_server"},
+		{name: "小驼峰", text: `{{ camel "user_info" }}`, want: "userInfo"},
+		{name: "小驼峰缩写", text: `{{ camel "APIKey" }}`, want: "apiKey"},
+		{name: "大驼峰", text: `{{ pascal "user_info" }}`, want: "UserInfo"},
+		{name: "大驼峰缩写", text: `{{ pascal "user_id" }}`, want: "UserId"},
+		{name: "短横线命名", text: `{{ kebab "UserInfo" }}`, want: "user-info"},
+		{name: "短横线命名缩写", text: `{{ kebab "HTTPServer" }}`, want: "http-server"},
+		{name: "首字母小写", text: `{{ lowerFirst "UserInfo" }}`, want: "userInfo"},
+		{name: "首字母小写开头缩写", text: `{{ lowerFirst "HTTPServer" }}`, want: "httpServer"},
+		{name: "首字母小写全部为缩写", text: `{{ lowerFirst "ID" }}`, want: "id"},
+		{name: "首字母小写末尾缩写", text: `{{ lowerFirst "UserID" }}`, want: "userID"},
+		{name: "首字母小写单个字母", text: `{{ lowerFirst "A" }}`, want: "a"},
+		{name: "首字母小写已是小写", text: `{{ lowerFirst "userInfo" }}`, want: "userInfo"},
+		{name: "首字母小写空字符串", text: `{{ lowerFirst "" }}`, want: ""},
+		{name: "首字母大写", text: `{{ upperFirst "userInfo" }}`, want: "UserInfo"},
+		{name: "首字母大写非 ASCII", text: `{{ upperFirst "éclair" }}`, want: "Éclair"},
+		{name: "复数", text: `{{ plural "UserCategory" }}`, want: "UserCategories"},
+		{name: "单数", text: `{{ singular "people" }}`, want: "person"},
+		{name: "管道组合", text: `{{ "user_category" | plural | pascal }}`, want: "UserCategories"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := render(t, tt.text, nil)
+			if got != tt.want {
+				t.Errorf("%s = %v, want %v", tt.text, got, tt.want)
+			}
+		})
+	}
+}
+
+// TestFuncMap_Helpers function    测试模板中的字符串及数据辅助函数.
+func TestFuncMap_Helpers(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+		data any
+		want string
+	}{
+		{name: "去除前缀", text: `{{ .Name | trimPrefix "Get" }}`, data: map[string]any{"Name": "GetUser"}, want: "User"},
+		{name: "去除后缀", text: `{{ .Name | trimSuffix "Service" }}`, data: map[string]any{"Name": "UserService"}, want: "User"},
+		{name: "替换", text: `{{ .Name | replace "_" "-" }}`, data: map[string]any{"Name": "a_b_c"}, want: "a-b-c"},
+		{name: "包含", text: `{{ if contains "Id" .Name }}yes{{ end }}`, data: map[string]any{"Name": "UserId"}, want: "yes"},
+		{name: "拼接", text: `{{ join ", " .Names }}`, data: map[string]any{"Names": []int{1, 2, 3}}, want: "1, 2, 3"},
+		{name: "拼接非列表", text: `{{ join ", " .Name }}`, data: map[string]any{"Name": "a"}, want: "a"},
+		{name: "字符串字面量", text: `{{ quote .Name }}`, data: map[string]any{"Name": `a"b`}, want: `"a\"b"`},
+		{name: "缩进", text: `{{ indent 2 .Body }}`, data: map[string]any{"Body": "a\n\nb"}, want: "  a\n\n  b"},
+		{name: "默认值", text: `{{ .Desc | default "无" }}`, data: map[string]any{"Desc": ""}, want: "无"},
+		{name: "默认值空列表", text: `{{ .List | default "无" }}`, data: map[string]any{"List": []string{}}, want: "无"},
+		{name: "默认值非空", text: `{{ .Desc | default "无" }}`, data: map[string]any{"Desc": "有"}, want: "有"},
+		{name: "映射", text: `{{ with dict "A" 1 "B" "b" }}{{ .A }}{{ .B }}{{ end }}`, want: "1b"},
+		{name: "列表", text: `{{ join "," (list "a" "b") }}`, want: "a,b"},
+		{name: "可空类型", text: `{{ goType "sql.NullString" }}`, want: "string"},
+		{name: "泛型可空类型", text: `{{ goType "sql.Null[int]" }}`, want: "int"},
+		{name: "非可空类型", text: `{{ goType "*User" }}`, want: "*User"},
+		{name: "导入包名", text: `{{ importAlias "github.com/stoewer/go-strcase" }}`, want: "strcase"},
+		{name: "导入包名主版本号", text: `{{ importAlias "github.com/jackc/pgx/v5" }}`, want: "pgx"},
+		{name: "导入包名 gopkg.in", text: `{{ importAlias "gopkg.in/yaml.v3" }}`, want: "yaml"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := render(t, tt.text, tt.data)
+			if got != tt.want {
+				t.Errorf("%s = %v, want %v", tt.text, got, tt.want)
+			}
+		})
+	}
+}
+
+// TestFuncMap_Dict function    测试 dict 参数错误.
+func TestFuncMap_Dict(t *testing.T) {
+	for _, text := range []string{`{{ dict "A" }}`, `{{ dict 1 2 }}`} {
+		tmpl, err := NewTemplate("test").Parse(text)
+		if err != nil {
+			t.Fatalf("Parse(%s) error = %v", text, err)
+		}
+		if err = tmpl.Execute(&strings.Builder{}, nil); err == nil {
+			t.Errorf("%s error = nil, want error", text)
+		}
+	}
+}
+
+// render function    使用模板函数渲染模板文本.
+func render(t *testing.T, text string, data any) string {
+	t.Helper()
+	tmpl, err := NewTemplate("test").Parse(text)
+	if err != nil {
+		t.Fatalf("Parse(%s) error = %v", text, err)
+	}
+	var bf strings.Builder
+	if err = tmpl.Execute(&bf, data); err != nil {
+		t.Fatalf("Execute(%s) error = %v", text, err)
+	}
+	return bf.String()
+}
@@ -0,0 +1,162 @@
+package utils
+
+import (
+	"strings"
+	"unicode"
+)
+
+// irregularPlurals 不规则复数形式.
+var irregularPlurals = map[string]string{
+	"person": "people",
+	"man":    "men",
+	"woman":  "women",
+	"child":  "children",
+	"foot":   "feet",
+	"tooth":  "teeth",
+	"goose":  "geese",
+	"mouse":  "mice",
+	"ox":     "oxen",
+	"datum":  "data",
+	"index":  "indices",
+	"matrix": "matrices",
+	"vertex": "vertices",
+	"leaf":   "leaves",
+	"life":   "lives",
+	"knife":  "knives",
+	"wife":   "wives",
+	"half":   "halves",
+	"shelf":  "shelves",
+	"wolf":   "wolves",
+	"thief":  "thieves",
+	"status": "statuses",
+	"bus":    "buses",
+	"campus": "campuses",
+	"virus":  "viruses",
+	"crisis": "crises",
+	"thesis": "theses",
+}
+
+// irregularSingulars 不规则复数形式对应的单数形式.
+var irregularSingulars = func() map[string]string {
+	m := make(map[string]string, len(irregularPlurals))
+	for singular, plural := range irregularPlurals {
+		m[plural] = singular
+	}
+	return m
+}()
+
+// uncountables 单复数相同的单词.
+var uncountables = map[string]bool{
+	"information": true, "equipment": true, "news": true, "series": true, "species": true,
+	"sheep": true, "fish": true, "deer": true, "money": true, "rice": true, "media": true,
+	"metadata": true, "data": true, "feedback": true,
+}
+
+// Plural function    返回单词的英文复数形式，驼峰及蛇形命名仅转换最后一个单词，如 UserCategory 返回 UserCategories.
+func Plural(word string) string {
+	return inflectLastWord(word, func(w string) string {
+		if uncountables[w] {
+			return w
+		}
+		if p, ok := irregularPlurals[w]; ok {
+			return p
+		}
+		if _, ok := irregularSingulars[w]; ok {
+			return w
+		}
+		switch {
+		case strings.HasSuffix(w, "y") && len(w) > 1 && !isVowel(w[len(w)-2]):
+			return w[:len(w)-1] + "ies"
+		case strings.HasSuffix(w, "sis"):
+			return w[:len(w)-2] + "es"
+		case hasAnySuffix(w, "s", "x", "z", "ch", "sh"):
+			if Singular(w) != w {
+				// 已是复数形式
+				return w
+			}
+			return w + "es"
+		default:
+			return w + "s"
+		}
+	})
+}
+
+// Singular function    返回单词的英文单数形式，驼峰及蛇形命名仅转换最后一个单词，如 UserCategories 返回 UserCategory.
+func Singular(word string) string {
+	return inflectLastWord(word, func(w string) string {
+		if uncountables[w] {
+			return w
+		}
+		if s, ok := irregularSingulars[w]; ok {
+			return s
+		}
+		if _, ok := irregularPlurals[w]; ok {
+			return w
+		}
+		switch {
+		case strings.HasSuffix(w, "ies") && len(w) > 3:
+			return w[:len(w)-3] + "y"
+		case strings.HasSuffix(w, "yses"):
+			return w[:len(w)-2] + "is"
+		case hasAnySuffix(w, "sses", "xes", "zes", "ches", "shes"):
+			return w[:len(w)-2]
+		case hasAnySuffix(w, "ss", "us", "is"):
+			return w
+		case strings.HasSuffix(w, "s") && len(w) > 1:
+			return w[:len(w)-1]
+		default:
+			return w
+		}
+	})
+}
+
+// inflectLastWord function    对标识符的最后一个单词执行转换，保留原有的大小写.
+func inflectLastWord(word string, fn func(string) string) string {
+	// 连续的大写字母视为同一个单词，如 UserID 的最后一个单词为 ID
+	start, prev := 0, rune(0)
+	for i, r := range word {
+		if r == '_' || r == '-' || r == ' ' {
+			start = i + 1
+		} else if unicode.IsUpper(r) && i > 0 && !unicode.IsUpper(prev) {
+			start = i
+		}
+		prev = r
+	}
+	prefix, last := word[:start], word[start:]
+	if len(last) == 0 {
+		return word
+	}
+	// 缩写的复数形式如 APIs 按缩写 API 转换
+	if n := len(last); n > 2 && last[n-1] == 's' && last[:n-1] == strings.ToUpper(last[:n-1]) {
+		last = last[:n-1]
+	}
+
+	// 与原单词相同的部分保留原有大小写，其余部分全大写单词使用大写，否则使用小写
+	lower := strings.ToLower(last)
+	ret := []byte(fn(lower))
+	allUpper := word == strings.ToUpper(word)
+	for i := range ret {
+		switch {
+		case i < len(last) && ret[i] == lower[i]:
+			ret[i] = last[i]
+		case allUpper:
+			ret[i] = byte(unicode.ToUpper(rune(ret[i])))
+		}
+	}
+	return prefix + string(ret)
+}
+
+// isVowel function    判断字母是否为元音.
+func isVowel(c byte) bool {
+	return strings.IndexByte("aeiou", c) >= 0
+}
+
+// hasAnySuffix function    判断字符串是否以任一后缀结尾.
+func hasAnySuffix(s string, suffixes ...string) bool {
+	for _, suffix := range suffixes {
+		if strings.HasSuffix(s, suffix) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,96 @@
+package utils
+
+import (
+	"testing"
+)
+
+// TestPlural function    测试转换英文复数形式.
+func TestPlural(t *testing.T) {
+	tests := []struct {
+		name string
+		word string
+		want string
+	}{
+		{name: "规则名词", word: "user", want: "users"},
+		{name: "辅音加 y", word: "category", want: "categories"},
+		{name: "元音加 y", word: "day", want: "days"},
+		{name: "s 结尾", word: "address", want: "addresses"},
+		{name: "x 结尾", word: "box", want: "boxes"},
+		{name: "ch 结尾", word: "match", want: "matches"},
+		{name: "sis 结尾", word: "analysis", want: "analyses"},
+		{name: "不规则名词 person", word: "person", want: "people"},
+		{name: "不规则名词 child", word: "child", want: "children"},
+		{name: "不规则名词 mouse", word: "mouse", want: "mice"},
+		{name: "不规则名词 index", word: "index", want: "indices"},
+		{name: "f 结尾的不规则名词", word: "leaf", want: "leaves"},
+		{name: "us 结尾", word: "status", want: "statuses"},
+		{name: "不可数名词", word: "sheep", want: "sheep"},
+		{name: "不可数名词 data", word: "data", want: "data"},
+		{name: "已是复数", word: "users", want: "users"},
+		{name: "已是不规则复数", word: "people", want: "people"},
+		{name: "已是 es 复数", word: "boxes", want: "boxes"},
+		{name: "大驼峰", word: "UserCategory", want: "UserCategories"},
+		{name: "大驼峰不规则名词", word: "TeamPerson", want: "TeamPeople"},
+		{name: "小驼峰", word: "orderItem", want: "orderItems"},
+		{name: "蛇形命名", word: "user_category", want: "user_categories"},
+		{name: "短横线命名", word: "order-item", want: "order-items"},
+		{name: "首字母大写保留", word: "Person", want: "People"},
+		{name: "全大写", word: "USER_STATUS", want: "USER_STATUSES"},
+		{name: "末尾缩写", word: "UserID", want: "UserIDs"},
+		{name: "缩写", word: "IDs", want: "IDs"},
+		{name: "缩写复数", word: "UserAPIs", want: "UserAPIs"},
+		{name: "开头缩写", word: "HTTPServer", want: "HTTPServers"},
+		{name: "空字符串", word: "", want: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Plural(tt.word); got != tt.want {
+				t.Errorf("Plural(%q) = %v, want %v", tt.word, got, tt.want)
+			}
+		})
+	}
+}
+
+// TestSingular function    测试转换英文单数形式.
+func TestSingular(t *testing.T) {
+	tests := []struct {
+		name string
+		word string
+		want string
+	}{
+		{name: "规则名词", word: "users", want: "user"},
+		{name: "ies 结尾", word: "categories", want: "category"},
+		{name: "ses 结尾", word: "addresses", want: "address"},
+		{name: "xes 结尾", word: "boxes", want: "box"},
+		{name: "ches 结尾", word: "matches", want: "match"},
+		{name: "yses 结尾", word: "analyses", want: "analysis"},
+		{name: "不规则名词 people", word: "people", want: "person"},
+		{name: "不规则名词 children", word: "children", want: "child"},
+		{name: "不规则名词 mice", word: "mice", want: "mouse"},
+		{name: "不规则名词 indices", word: "indices", want: "index"},
+		{name: "ves 结尾的不规则名词", word: "leaves", want: "leaf"},
+		{name: "us 结尾", word: "statuses", want: "status"},
+		{name: "已是单数 us 结尾", word: "status", want: "status"},
+		{name: "已是单数 ss 结尾", word: "class", want: "class"},
+		{name: "不可数名词", word: "news", want: "news"},
+		{name: "已是单数", word: "user", want: "user"},
+		{name: "已是不规则单数", word: "person", want: "person"},
+		{name: "大驼峰", word: "UserCategories", want: "UserCategory"},
+		{name: "首字母大写保留", word: "Children", want: "Child"},
+		{name: "蛇形命名", word: "order_items", want: "order_item"},
+		{name: "全大写", word: "USER_STATUSES", want: "USER_STATUS"},
+		{name: "末尾缩写", word: "UserIDs", want: "UserID"},
+		{name: "缩写复数", word: "APIs", want: "API"},
+		{name: "缩写单数", word: "UserAPI", want: "UserAPI"},
+		{name: "空字符串", word: "", want: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Singular(tt.word); got != tt.want {
+				t.Errorf("Singular(%q) = %v, want %v", tt.word, got, tt.want)
+			}
+		})
+	}
+}
@@ -2,6 +2,7 @@ package utils
 
 import (
 	"fmt"
+	"os"
 	"os/exec"
 	"path/filepath"
 	"strings"
@@ -17,8 +18,37 @@ var (
 
 var (
 	modFilepath *string
+	// projectDir 通过 --project-dir 指定的项目根目录.
+	projectDir string
 )
 
+// ProjectDirEnv 指定项目根目录的环境变量.
+const ProjectDirEnv = "GSUS_PROJECT_DIR"
+
+// SetProjectDir function    设置项目根目录，优先于 GSUS_PROJECT_DIR 环境变量.
+// 需在首次获取项目目录之前设置.
+func SetProjectDir(dir string) {
+	projectDir = dir
+}
+
+// projectDirOverride function    返回通过参数或环境变量指定的项目根目录，未指定时返回空字符串.
+func projectDirOverride() (dir string, err error) {
+	dir = projectDir
+	if len(dir) == 0 {
+		dir = os.Getenv(ProjectDirEnv)
+	}
+	if len(dir) == 0 {
+		return "", nil
+	}
+	if dir, err = filepath.Abs(dir); err != nil {
+		return "", errors.WrapWithCode(err, errors.ErrCodeConfig, fmt.Sprintf("解析项目目录失败: %s", err))
+	}
+	if info, err := os.Stat(dir); err != nil || !info.IsDir() {
+		return "", errors.New(errors.ErrCodeConfig, fmt.Sprintf("项目目录不存在: %s", dir))
+	}
+	return dir, nil
+}
+
 // FixFilepathByProjectDir function    将相对路径转换为基于项目根目录的绝对路径.
 func FixFilepathByProjectDir(fp ...*string) (err error) {
 	dir, err := GetProjectDir()
@@ -35,7 +65,11 @@ func FixFilepathByProjectDir(fp ...*string) (err error) {
 }
 
 // GetProjectDir function    获取项目根目录.
+// 优先使用 --project-dir 或 GSUS_PROJECT_DIR 指定的目录，否则为 go.mod 所在目录.
 func GetProjectDir() (path string, err error) {
+	if path, err = projectDirOverride(); err != nil || len(path) > 0 {
+		return path, err
+	}
 	ret, err := GetModFilepath()
 	if err != nil {
 		return "", errors.WrapWithCode(err, errors.ErrCodeConfig, fmt.Sprintf("获取 go.mod 路径失败: %s", err))
@@ -49,18 +83,21 @@ func GetModFilepath() (path string, err error) {
 	if modFilepath != nil {
 		return *modFilepath, nil
 	}
-	ret, err := exec.Command("go", "env", "GOMOD").Output()
+	ret, err := goCommand("env", "GOMOD").Output()
 	if err != nil {
 		return "", errors.WrapWithCode(err, errors.ErrCodeConfig, "执行 go env GOMOD 失败")
 	}
 	path = strings.TrimSpace(string(ret))
+	if len(path) == 0 || path == os.DevNull {
+		return "", errors.New(errors.ErrCodeConfig, "当前目录不在 Go 模块中，请使用 --project-dir 或 "+ProjectDirEnv+" 指定项目目录")
+	}
 	modFilepath = &path
 	return
 }
 
 // GetModBase function    获取模块基础路径.
 func GetModBase() (path string, err error) {
-	ret, err := exec.Command("go", "list", "-m").Output()
+	ret, err := goCommand("list", "-m").Output()
 	if err != nil {
 		return "", errors.WrapWithCode(err, errors.ErrCodeConfig, "执行 go list -m 失败")
 	}
@@ -78,7 +115,7 @@ func GetPathModPkg(fp string) (pkg string, err error) {
 	if err = FixFilepathByProjectDir(&fp); err != nil {
 		return "", errors.WrapWithCode(err, errors.ErrCodeConfig, fmt.Sprintf("修正文件路径失败: %s", err))
 	}
-	ret, err := exec.Command("go", "list", fp).Output()
+	ret, err := goCommand("list", fp).Output()
 	if err != nil {
 		return "", errors.WrapWithCode(err, errors.ErrCodeConfig, fmt.Sprintf("执行 go list 失败: %s", err))
 	}
@@ -86,3 +123,12 @@ func GetPathModPkg(fp string) (pkg string, err error) {
 	modPkgTmp[fp] = pkg
 	return
 }
+
+// goCommand function    创建在项目目录下执行的 go 命令，未指定项目目录时在当前目录执行.
+func goCommand(args ...string) *exec.Cmd {
+	cmd := exec.Command("go", args...)
+	if dir, err := projectDirOverride(); err == nil {
+		cmd.Dir = dir
+	}
+	return cmd
+}